@@ -1,7 +1,7 @@
 package zabbix
 
 import (
-	"github.com/AlekSi/reflector"
+	"context"
 )
 
 // Application - https://www.zabbix.com/documentation/2.2/manual/appendix/api/application/definitions
@@ -15,95 +15,93 @@ type Application struct {
 // Applications - the array of Application
 type Applications []Application
 
+// requireApplications - Returns error for Zabbix 5.4+, where applications were replaced by item tags.
+func (api *API) requireApplications(ctx context.Context) error {
+	return api.require(ctx, "Applications", func(c Capabilities) bool { return c.Applications })
+}
+
+// applications - Returns Resource for application methods.
+func (api *API) applications() *Resource[Application] {
+	return NewResource(api, "application", "applicationid", func(a *Application) *string { return &a.ID })
+}
+
 // ApplicationsGet - Wrapper for application.get: https://www.zabbix.com/documentation/2.2/manual/appendix/api/application/get
+// Application wrappers return error matching errors.ErrUnsupported for Zabbix 5.4+, use item tags instead.
 func (api *API) ApplicationsGet(params Params) (res Applications, err error) {
-	if _, present := params["output"]; !present {
-		params["output"] = "extend"
-	}
-	response, err := api.CallWithError("application.get", params)
-	if err != nil {
+	return api.ApplicationsGetContext(context.Background(), params)
+}
+
+// ApplicationsGetContext - Same as ApplicationsGet, but bound to ctx.
+func (api *API) ApplicationsGetContext(ctx context.Context, params Params) (res Applications, err error) {
+	if err = api.requireApplications(ctx); err != nil {
 		return
 	}
-
-	reflector.MapsToStructs2(response.Result.([]interface{}), &res, reflector.Strconv, "json")
+	res, err = api.applications().GetContext(ctx, params)
 	return
 }
 
 // ApplicationGetByID - Gets application by Id only if there is exactly 1 matching application.
 func (api *API) ApplicationGetByID(id string) (res *Application, err error) {
-	apps, err := api.ApplicationsGet(Params{"applicationids": id})
-	if err != nil {
-		return
-	}
+	return api.ApplicationGetByIDContext(context.Background(), id)
+}
 
-	if len(apps) == 1 {
-		res = &apps[0]
-	} else {
-		e := ExpectedOneResult(len(apps))
-		err = &e
+// ApplicationGetByIDContext - Same as ApplicationGetByID, but bound to ctx.
+func (api *API) ApplicationGetByIDContext(ctx context.Context, id string) (res *Application, err error) {
+	if err = api.requireApplications(ctx); err != nil {
+		return
 	}
-	return
+	return api.applications().GetByIDContext(ctx, id)
 }
 
 // ApplicationGetByHostIDAndName -Gets application by host Id and name only if there is exactly 1 matching application.
 func (api *API) ApplicationGetByHostIDAndName(hostID, name string) (res *Application, err error) {
-	apps, err := api.ApplicationsGet(Params{"hostids": hostID, "filter": map[string]string{"name": name}})
-	if err != nil {
-		return
-	}
+	return api.ApplicationGetByHostIDAndNameContext(context.Background(), hostID, name)
+}
 
-	if len(apps) == 1 {
-		res = &apps[0]
-	} else {
-		e := ExpectedOneResult(len(apps))
-		err = &e
+// ApplicationGetByHostIDAndNameContext - Same as ApplicationGetByHostIDAndName, but bound to ctx.
+func (api *API) ApplicationGetByHostIDAndNameContext(ctx context.Context, hostID, name string) (res *Application, err error) {
+	if err = api.requireApplications(ctx); err != nil {
+		return
 	}
-	return
+	return api.applications().GetOneContext(ctx, Params{"hostids": hostID, "filter": map[string]string{"name": name}})
 }
 
 // ApplicationsCreate - Wrapper for application.create: https://www.zabbix.com/documentation/2.2/manual/appendix/api/application/create
 func (api *API) ApplicationsCreate(apps Applications) (err error) {
-	response, err := api.CallWithError("application.create", apps)
-	if err != nil {
-		return
-	}
+	return api.ApplicationsCreateContext(context.Background(), apps)
+}
 
-	result := response.Result.(map[string]interface{})
-	applicationids := result["applicationids"].([]interface{})
-	for i, id := range applicationids {
-		apps[i].ID = id.(string)
+// ApplicationsCreateContext - Same as ApplicationsCreate, but bound to ctx.
+func (api *API) ApplicationsCreateContext(ctx context.Context, apps Applications) (err error) {
+	if err = api.requireApplications(ctx); err != nil {
+		return
 	}
-	return
+	return api.applications().CreateContext(ctx, apps)
 }
 
 // ApplicationsDelete - Wrapper for application.delete: https://www.zabbix.com/documentation/2.2/manual/appendix/api/application/delete
 // Cleans ApplicationId in all apps elements if call succeed.
 func (api *API) ApplicationsDelete(apps Applications) (err error) {
-	ids := make([]string, len(apps))
-	for i, app := range apps {
-		ids[i] = app.ID
-	}
+	return api.ApplicationsDeleteContext(context.Background(), apps)
+}
 
-	err = api.ApplicationsDeleteByIds(ids)
-	if err == nil {
-		for i := range apps {
-			apps[i].ID = ""
-		}
+// ApplicationsDeleteContext - Same as ApplicationsDelete, but bound to ctx.
+func (api *API) ApplicationsDeleteContext(ctx context.Context, apps Applications) (err error) {
+	if err = api.requireApplications(ctx); err != nil {
+		return
 	}
-	return
+	return api.applications().DeleteContext(ctx, apps)
 }
 
 // ApplicationsDeleteByIds - Wrapper for application.delete: https://www.zabbix.com/documentation/2.2/manual/appendix/api/application/delete
 func (api *API) ApplicationsDeleteByIds(ids []string) (err error) {
-	response, err := api.CallWithError("application.delete", ids)
-	if err != nil {
-		return
-	}
+	return api.ApplicationsDeleteByIdsContext(context.Background(), ids)
+}
 
-	result := response.Result.(map[string]interface{})
-	applicationids := result["applicationids"].([]interface{})
-	if len(ids) != len(applicationids) {
-		err = &ExpectedMore{len(ids), len(applicationids)}
+// ApplicationsDeleteByIdsContext - Same as ApplicationsDeleteByIds, but bound to ctx.
+func (api *API) ApplicationsDeleteByIdsContext(ctx context.Context, ids []string) (err error) {
+	if err = api.requireApplications(ctx); err != nil {
+		return
 	}
-	return
+	return api.applications().DeleteByIdsContext(ctx, ids)
 }
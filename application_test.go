@@ -1,6 +1,7 @@
 package zabbix
 
 import (
+	"errors"
 	"fmt"
 	"math/rand"
 	"reflect"
@@ -73,3 +74,24 @@ func TestApplications(t *testing.T) {
 
 	DeleteApplication(app, t)
 }
+
+func TestApplicationsUnsupported(t *testing.T) {
+	api, calls := newMethodAPI(map[string]string{"application.get": `[]`, "item.get": `[]`})
+	if _, err := api.ApplicationsGet(Params{}); err != nil {
+		t.Fatal(err)
+	}
+
+	api.SetVersion(ServerVersion{5, 4, 0})
+	if _, err := api.ApplicationsGet(Params{}); !errors.Is(err, errors.ErrUnsupported) {
+		t.Errorf("Expected ErrUnsupported, got %v", err)
+	}
+	if err := api.ApplicationsCreate(Applications{{HostID: "1", Name: "app"}}); !errors.Is(err, errors.ErrUnsupported) {
+		t.Errorf("Expected ErrUnsupported, got %v", err)
+	}
+	if _, err := api.ItemsGetByApplicationID("1"); !errors.Is(err, errors.ErrUnsupported) {
+		t.Errorf("Expected ErrUnsupported, got %v", err)
+	}
+	if len(*calls) != 1 {
+		t.Errorf("Expected 1 call, got %d", len(*calls))
+	}
+}
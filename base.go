@@ -2,12 +2,17 @@ package zabbix
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"log"
+	"log/slog"
 	"net/http"
+	"strings"
+	"sync"
 	"sync/atomic"
+	"time"
 )
 
 // Params - param map
@@ -58,13 +63,53 @@ func (e *ExpectedMore) Error() string {
 	return fmt.Sprintf("Expected %d, got %d.", e.Expected, e.Got)
 }
 
+// AuthMode - how auth token is passed to server
+type AuthMode int
+
+const (
+	// AuthAuto - (default) Authorization header for Zabbix 6.4+, request body field for older versions
+	AuthAuto AuthMode = 0
+	// AuthBody - "auth" field of request body, removed in Zabbix 7.2
+	AuthBody AuthMode = 1
+	// AuthHeader - "Authorization: Bearer" HTTP header, Zabbix 6.4+
+	AuthHeader AuthMode = 2
+)
+
 // API - api define
+// Exported fields should be set before API is used; all methods are safe for concurrent use.
 type API struct {
-	Auth   string      // auth token, filled by Login()
-	Logger *log.Logger // request/response logger, nil by default
-	url    string
-	c      http.Client
-	id     int32
+	AuthMode AuthMode     // how auth token is passed to server, AuthAuto by default
+	Logger   *log.Logger  // request/response logger, nil by default
+	Slog     *slog.Logger // structured logger: summaries at Info (Warn on errors), bodies at Debug; nil by default
+	Retry    RetryPolicy  // retry policy for failed calls and batches, not used by streamed calls; nil by default
+
+	// RedactFields are names of additional fields, like "value" of macros, which values are hidden
+	// from Logger and Slog. Passwords, tokens and session Ids are always hidden.
+	RedactFields []string
+
+	// Limiter limits rate and concurrency of all HTTP requests, MethodLimiters - of requests
+	// for given methods (like "history.get") in addition to Limiter. Both are nil by default.
+	Limiter        *Limiter
+	MethodLimiters map[string]*Limiter
+
+	// Interceptors are called around every call, including Login() and Version(), but not batches
+	// and streamed calls (CallEach, HistorysGetEach and Resource.Each). The first one is the outermost.
+	Interceptors []Interceptor
+
+	// Credentials enable automatic re-login: if call fails because session expired,
+	// Login is called once with provided credentials and call is replayed. Nil by default.
+	Credentials CredentialsProvider
+
+	url string
+	c   http.Client
+	id  int32
+
+	authM    sync.RWMutex
+	auth     string // auth token, filled by Login() or SetAuth()
+	session  bool   // true if auth was filled by Login()
+	reloginM sync.Mutex
+	versionM sync.Mutex
+	version  *ServerVersion // cached by DetectVersion()
 }
 
 // NewAPI - Creates new API access object.
@@ -75,7 +120,33 @@ func NewAPI(url string) (api *API) {
 	return &API{url: url, c: http.Client{}}
 }
 
+// NewAPIWithToken - Creates new API access object which uses API token (Zabbix 5.4+) instead of Login().
+func NewAPIWithToken(url, token string) (api *API) {
+	api = NewAPI(url)
+	api.SetAuth(token)
+	return
+}
+
+// Auth - Returns current auth token: session Id filled by Login() or API token set by SetAuth().
+func (api *API) Auth() string {
+	api.authM.RLock()
+	defer api.authM.RUnlock()
+	return api.auth
+}
+
+// SetAuth - Sets auth token used by all following calls, for example API token (Zabbix 5.4+).
+func (api *API) SetAuth(auth string) {
+	api.setAuth(auth, false)
+}
+
+func (api *API) setAuth(auth string, session bool) {
+	api.authM.Lock()
+	api.auth, api.session = auth, session
+	api.authM.Unlock()
+}
+
 // SetClient - Allows one to use specific http.Client, for example with InsecureSkipVerify transport.
+// It should be called before API is used.
 func (api *API) SetClient(c *http.Client) {
 	api.c = *c
 }
@@ -86,86 +157,263 @@ func (api *API) printf(format string, v ...interface{}) {
 	}
 }
 
-func (api *API) callBytes(method string, params interface{}) (b []byte, err error) {
-	id := atomic.AddInt32(&api.id, 1)
-	jsonobj := request{"2.0", method, params, api.Auth, id}
-	b, err = json.Marshal(jsonobj)
+// anonymousMethods - methods which are called without auth (lower case)
+var anonymousMethods = map[string]bool{
+	"apiinfo.version":          true,
+	"user.login":               true,
+	"user.checkauthentication": true,
+}
+
+// isAnonymous - Returns true if method should be called without auth.
+func isAnonymous(method string) bool {
+	return anonymousMethods[strings.ToLower(method)]
+}
+
+// authHeader - Returns true if auth should be passed in Authorization header, false if in request body.
+// Result depends on AuthMode and server version only, not on called method.
+// With AuthBody error is returned for servers known to ignore auth in body, instead of unclear API error.
+func (api *API) authHeader(ctx context.Context, auth string) (header bool, err error) {
+	if auth == "" {
+		return
+	}
+	switch api.AuthMode {
+	case AuthBody:
+		if v, e := api.DetectVersionContext(ctx); e == nil && !v.Capabilities().AuthBody {
+			err = fmt.Errorf("Auth in request body is not supported by Zabbix %s, use AuthHeader or AuthAuto.", v)
+		}
+		return
+	case AuthHeader:
+		return true, nil
+	}
+
+	v, err := api.DetectVersionContext(ctx)
+	if err != nil {
+		return
+	}
+	return v.Capabilities().AuthHeader, nil
+}
+
+// marshalRequest - Returns request Id and body for method call with auth.
+// If auth should be passed in Authorization header, it is returned as headerAuth.
+func (api *API) marshalRequest(ctx context.Context, method string, params interface{}, auth string) (id int32, body []byte, headerAuth string, err error) {
+	// auth is passed to APIInfo.version only by Version() for Zabbix 2.2, where it is always in body;
+	// detecting version here would recurse
+	header := false
+	if !strings.EqualFold(method, "APIInfo.version") {
+		if header, err = api.authHeader(ctx, auth); err != nil {
+			return
+		}
+	}
+
+	id = atomic.AddInt32(&api.id, 1)
+	jsonobj := request{"2.0", method, params, auth, id}
+	if header {
+		jsonobj.Auth, headerAuth = "", auth
+	}
+	body, err = json.Marshal(jsonobj)
+	return
+}
+
+func (api *API) callBytes(ctx context.Context, method string, params interface{}, auth string) (b []byte, err error) {
+	id, body, headerAuth, err := api.marshalRequest(ctx, method, params, auth)
 	if err != nil {
 		return
 	}
-	api.printf("Request (POST): %s", b)
+	return api.send(ctx, method, id, body, headerAuth)
+}
+
+// send - Posts marshaled body, retrying it according to api.Retry.
+// method and id are used only for logging and retry classification, headerAuth is passed to post.
+func (api *API) send(ctx context.Context, method string, id int32, body []byte, headerAuth string) (b []byte, err error) {
+	api.logRequest(ctx, method, id, body)
+
+	for attempt := 1; ; attempt++ {
+		var release func()
+		if release, err = api.acquire(ctx, method); err != nil {
+			return
+		}
+
+		a := &Attempt{Method: method, Number: attempt}
+		start := time.Now()
+		b, a.StatusCode, err = api.post(ctx, body, headerAuth)
+		release()
+		if _, ok := err.(*HTTPError); !ok {
+			a.Err = err
+		}
+		if err == nil && (api.Retry != nil || api.Slog != nil) {
+			a.APIError = peekError(b)
+		}
+		api.logResponse(ctx, a, id, time.Since(start), len(body), b)
+		if api.Retry == nil || ctx.Err() != nil {
+			return
+		}
+
+		delay, retry := api.Retry.Retry(a)
+		if !retry {
+			return
+		}
+		api.printf("Retry   : %s attempt %d in %s", method, attempt+1, delay)
+		if err = sleepContext(ctx, delay); err != nil {
+			return
+		}
+	}
+}
 
-	req, err := http.NewRequest("POST", api.url, bytes.NewReader(b))
+// do - Makes single HTTP request. If headerAuth is not empty, it is passed in Authorization header.
+func (api *API) do(ctx context.Context, body []byte, headerAuth string) (res *http.Response, err error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", api.url, bytes.NewReader(body))
 	if err != nil {
 		return
 	}
-	req.ContentLength = int64(len(b))
+	req.ContentLength = int64(len(body))
 	req.Header.Add("Content-Type", "application/json-rpc")
 	req.Header.Add("User-Agent", "github.com/AlekSi/zabbix")
+	if headerAuth != "" {
+		req.Header.Add("Authorization", "Bearer "+headerAuth)
+	}
+	return api.c.Do(req)
+}
 
-	res, err := api.c.Do(req)
+// post - Makes single HTTP request and reads response.
+// Response with non-2xx status or non-JSON content is returned as *HTTPError.
+func (api *API) post(ctx context.Context, body []byte, headerAuth string) (b []byte, status int, err error) {
+	res, err := api.do(ctx, body, headerAuth)
 	if err != nil {
-		api.printf("Error   : %s", err)
 		return
 	}
 	defer res.Body.Close()
 
-	b, err = ioutil.ReadAll(res.Body)
-	api.printf("Response (%d): %s", res.StatusCode, b)
+	status = res.StatusCode
+	if b, err = ioutil.ReadAll(res.Body); err != nil {
+		return
+	}
+
+	if status < 200 || status > 299 || !isJSON(res.Header.Get("Content-Type"), b) {
+		err = newHTTPError(res, b)
+	}
 	return
 }
 
-// Call - Calls specified API method. Uses api.Auth if not empty.
-// err is something network or marshaling related. Caller should inspect response.Error to get API error.
+// isJSON - Returns true if response looks like JSON: either by content type,
+// or by body for servers and proxies which set wrong one.
+func isJSON(contentType string, b []byte) bool {
+	if strings.Contains(contentType, "json") {
+		return true
+	}
+	b = bytes.TrimSpace(b)
+	return len(b) > 0 && (b[0] == '{' || b[0] == '[')
+}
+
+// Call - Calls specified API method. Uses api.Auth() if not empty.
+// err is something network, HTTP (*HTTPError) or marshaling related. Caller should inspect response.Error to get API error.
 func (api *API) Call(method string, params interface{}) (response Response, err error) {
-	b, err := api.callBytes(method, params)
-	if err == nil {
-		err = json.Unmarshal(b, &response)
+	return api.CallContext(context.Background(), method, params)
+}
+
+// CallContext - Same as Call, but the HTTP request is bound to ctx,
+// so cancellation and deadlines abort the round-trip.
+func (api *API) CallContext(ctx context.Context, method string, params interface{}) (response Response, err error) {
+	auth := api.Auth()
+	response, err = api.call(ctx, method, params, auth)
+	if err == nil && api.Credentials != nil && method != "user.login" && IsSessionExpired(response.Error) {
+		if err = api.relogin(ctx, auth); err == nil {
+			response, err = api.call(ctx, method, params, api.Auth())
+		}
 	}
 	return
 }
 
+// call - Calls method with given auth token through api.Interceptors.
+func (api *API) call(ctx context.Context, method string, params interface{}, auth string) (response Response, err error) {
+	invoker := func(ctx context.Context, method string, params interface{}) (response Response, err error) {
+		b, err := api.callBytes(ctx, method, params, auth)
+		if err != nil {
+			return
+		}
+
+		if raw, _ := ctx.Value(rawResultKey{}).(bool); !raw {
+			err = json.Unmarshal(b, &response)
+			return
+		}
+		var r struct {
+			Jsonrpc string          `json:"jsonrpc"`
+			Error   *Error          `json:"error"`
+			Result  json.RawMessage `json:"result"`
+			ID      int32           `json:"id"`
+		}
+		if err = json.Unmarshal(b, &r); err == nil {
+			response = Response{r.Jsonrpc, r.Error, r.Result, r.ID}
+		}
+		return
+	}
+	return api.chain(invoker)(ctx, method, params)
+}
+
 // CallWithError - Uses Call() and then sets err to response.Error if former is nil and latter is not.
 func (api *API) CallWithError(method string, params interface{}) (response Response, err error) {
-	response, err = api.Call(method, params)
+	return api.CallWithErrorContext(context.Background(), method, params)
+}
+
+// CallWithErrorContext - Same as CallWithError, but uses CallContext.
+func (api *API) CallWithErrorContext(ctx context.Context, method string, params interface{}) (response Response, err error) {
+	response, err = api.CallContext(ctx, method, params)
 	if err == nil && response.Error != nil {
 		err = response.Error
 	}
 	return
 }
 
-// Login - Calls "user.login" API method and fills api.Auth field.
-// This method modifies API structure and should not be called concurrently with other methods.
+// Login - Calls "user.login" API method and sets auth token returned by api.Auth().
 func (api *API) Login(user, password string) (auth string, err error) {
+	return api.LoginContext(context.Background(), user, password)
+}
+
+// LoginContext - Same as Login, but bound to ctx.
+func (api *API) LoginContext(ctx context.Context, user, password string) (auth string, err error) {
+	// "user" is replaced by "username" in Zabbix 5.4, use old one if version is unknown
 	params := map[string]string{"user": user, "password": password}
-	response, err := api.CallWithError("user.login", params)
+	if caps, e := api.CapabilitiesContext(ctx); e == nil && caps.UsernameLogin {
+		params = map[string]string{"username": user, "password": password}
+	}
+	response, err := api.call(withRawResult(ctx), "user.login", params, "")
+	if err == nil && response.Error != nil {
+		err = response.Error
+	}
 	if err != nil {
 		return
 	}
 
-	auth = response.Result.(string)
-	api.Auth = auth
+	if err = decodeResult("user.login", response.Result, &auth); err != nil {
+		return
+	}
+	api.setAuth(auth, true)
 	return
 }
 
 // Version - Calls "APIInfo.version" API method.
-// This method temporary modifies API structure and should not be called concurrently with other methods.
 func (api *API) Version() (v string, err error) {
-	// temporary remove auth for this method to succeed
+	return api.VersionContext(context.Background())
+}
+
+// VersionContext - Same as Version, but bound to ctx.
+func (api *API) VersionContext(ctx context.Context) (v string, err error) {
+	// call without auth for this method to succeed
 	// https://www.zabbix.com/documentation/2.2/manual/appendix/api/apiinfo/version
-	auth := api.Auth
-	api.Auth = ""
-	response, err := api.CallWithError("APIInfo.version", Params{})
-	api.Auth = auth
+	response, err := api.call(withRawResult(ctx), "APIInfo.version", Params{}, "")
+	if err == nil && response.Error != nil {
+		err = response.Error
+	}
 
 	// despite what documentation says, Zabbix 2.2 requires auth, so we try again
-	if e, ok := err.(*Error); ok && e.Code == -32602 {
-		response, err = api.CallWithError("APIInfo.version", Params{})
+	if e, ok := err.(*Error); ok && e.Code == CodeInvalidParams {
+		if response, err = api.call(withRawResult(ctx), "APIInfo.version", Params{}, api.Auth()); err == nil && response.Error != nil {
+			err = response.Error
+		}
 	}
 	if err != nil {
 		return
 	}
 
-	v = response.Result.(string)
+	err = decodeResult("APIInfo.version", response.Result, &v)
 	return
 }
@@ -1,6 +1,8 @@
 package zabbix
 
 import (
+	"context"
+	"errors"
 	"log"
 	"math/rand"
 	"net/http"
@@ -71,6 +73,16 @@ func TestBadCalls(t *testing.T) {
 	}
 }
 
+func TestCallContextCanceled(t *testing.T) {
+	api := getAPI(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := api.CallContext(ctx, "APIInfo.version", Params{})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
+
 func TestVersion(t *testing.T) {
 	api := getAPI(t)
 	v, err := api.Version()
@@ -0,0 +1,150 @@
+package zabbix
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync/atomic"
+)
+
+// BatchCall - single call queued in Batch. Response, Err and result are filled by Batch.Send().
+type BatchCall struct {
+	Method   string
+	Params   interface{}
+	ID       int32
+	Response Response
+	Err      error // API error for this call (*Error) or result decoding error
+
+	result interface{}
+}
+
+// Batch - JSON-RPC 2.0 batch: queued calls are sent in a single HTTP request.
+// Batch should not be reused after Send.
+type Batch struct {
+	api   *API
+	calls []*BatchCall
+}
+
+// NewBatch - Creates new empty batch for this API.
+func (api *API) NewBatch() *Batch {
+	return &Batch{api: api}
+}
+
+// Add - Queues call of method with params. If result is not nil, it should be a pointer,
+// and "result" field of response is decoded into it by Send().
+func (b *Batch) Add(method string, params interface{}, result interface{}) *BatchCall {
+	call := &BatchCall{
+		Method: method,
+		Params: params,
+		ID:     atomic.AddInt32(&b.api.id, 1),
+		result: result,
+	}
+	b.calls = append(b.calls, call)
+	return call
+}
+
+// Calls - Returns queued calls in order they were added.
+func (b *Batch) Calls() []*BatchCall {
+	return b.calls
+}
+
+// Send - Sends all queued calls at once and fills their responses.
+// err is something network or marshaling related. Caller should inspect Err of every call to get API errors.
+func (b *Batch) Send() (err error) {
+	return b.SendContext(context.Background())
+}
+
+// SendContext - Same as Send, but bound to ctx.
+func (b *Batch) SendContext(ctx context.Context) (err error) {
+	if len(b.calls) == 0 {
+		return
+	}
+
+	// auth mode is the same for all calls, but anonymous calls are sent without auth
+	auth := b.api.Auth()
+	header, err := b.api.authHeader(ctx, auth)
+	if err != nil {
+		return
+	}
+	headerAuth := ""
+	if header {
+		headerAuth = auth
+	}
+
+	// retry policy sees batch as its first mutating method, so writes are not replayed by DefaultRetryable
+	method := b.calls[0].Method
+	reqs := make([]request, len(b.calls))
+	byID := make(map[int32]*BatchCall, len(b.calls))
+	methods := make(map[int32]string, len(b.calls))
+	for i, call := range b.calls {
+		reqs[i] = request{"2.0", call.Method, call.Params, auth, call.ID}
+		if header || isAnonymous(call.Method) {
+			reqs[i].Auth = ""
+		}
+		byID[call.ID] = call
+		methods[call.ID] = call.Method
+		if IsReadOnlyMethod(method) && !IsReadOnlyMethod(call.Method) {
+			method = call.Method
+		}
+	}
+
+	body, err := json.Marshal(reqs)
+	if err != nil {
+		return
+	}
+	res, err := b.api.send(withBatchMethods(ctx, methods), method, b.calls[0].ID, body, headerAuth)
+	if err != nil {
+		return
+	}
+
+	// whole batch may be rejected with a single error object, any other object is unexpected
+	res = bytes.TrimSpace(res)
+	if len(res) > 0 && res[0] == '{' {
+		var response Response
+		if err = json.Unmarshal(res, &response); err == nil {
+			err = &UnexpectedResult{Method: method, Result: response.Result, Err: errors.New("Expected array of responses.")}
+			if response.Error != nil {
+				err = response.Error
+			}
+		}
+		for _, call := range b.calls {
+			call.Err = err
+		}
+		return
+	}
+
+	var responses []struct {
+		Jsonrpc string          `json:"jsonrpc"`
+		Error   *Error          `json:"error"`
+		Result  json.RawMessage `json:"result"`
+		ID      int32           `json:"id"`
+	}
+	if err = json.Unmarshal(res, &responses); err != nil {
+		return
+	}
+
+	for _, r := range responses {
+		call := byID[r.ID]
+		if call == nil {
+			continue
+		}
+		delete(byID, r.ID)
+
+		call.Response = Response{Jsonrpc: r.Jsonrpc, Error: r.Error, ID: r.ID}
+		if r.Error != nil {
+			call.Err = r.Error
+			continue
+		}
+		if len(r.Result) > 0 {
+			if call.Err = json.Unmarshal(r.Result, &call.Response.Result); call.Err == nil && call.result != nil {
+				call.Err = json.Unmarshal(r.Result, call.result)
+			}
+		}
+	}
+	for id, call := range byID {
+		call.Err = fmt.Errorf("No response for batch call %d (%s).", id, call.Method)
+	}
+	return
+}
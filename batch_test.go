@@ -0,0 +1,125 @@
+package zabbix
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestBatch(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var reqs []request
+		b, _ := ioutil.ReadAll(r.Body)
+		if err := json.Unmarshal(b, &reqs); err != nil || len(reqs) != 2 {
+			t.Errorf("Bad batch: %s", b)
+			return
+		}
+		// answer in reverse order to check demultiplexing
+		fmt.Fprintf(w, `[{"jsonrpc":"2.0","error":{"code":-32602,"message":"Invalid params.","data":"No permissions."},"id":%d},`+
+			`{"jsonrpc":"2.0","result":"3.0.0","id":%d}]`, reqs[1].ID, reqs[0].ID)
+	}))
+	defer srv.Close()
+
+	batch := NewAPI(srv.URL).NewBatch()
+	var version string
+	c1 := batch.Add("APIInfo.version", Params{}, &version)
+	c2 := batch.Add("host.create", Hosts{}, nil)
+	if err := batch.Send(); err != nil {
+		t.Fatal(err)
+	}
+
+	if c1.Err != nil || version != "3.0.0" || c1.Response.Result != "3.0.0" {
+		t.Errorf("Bad first call: %#v %q", c1, version)
+	}
+	if e, ok := c2.Err.(*Error); !ok || e.Code != -32602 {
+		t.Errorf("Bad second call: %#v", c2)
+	}
+}
+
+func TestBatchSingleObject(t *testing.T) {
+	for body, isAPIError := range map[string]bool{
+		`{"jsonrpc":"2.0","result":"x","id":1}`:                                                      false,
+		`{"jsonrpc":"2.0","error":{"code":-32600,"message":"Invalid request.","data":""},"id":null}`: true,
+	} {
+		api := newResultAPI("[]")
+		api.SetClient(&http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Type": {"application/json"}},
+				Body:       ioutil.NopCloser(strings.NewReader(body)),
+			}, nil
+		})})
+
+		batch := api.NewBatch()
+		c := batch.Add("host.get", Params{}, nil)
+		err := batch.Send()
+		var apiErr *Error
+		var unexpected *UnexpectedResult
+		if isAPIError && !errors.As(err, &apiErr) || !isAPIError && !errors.As(err, &unexpected) {
+			t.Errorf("%s: unexpected error %v", body, err)
+		}
+		if c.Err != err {
+			t.Errorf("%s: expected call error %v, got %v", body, err, c.Err)
+		}
+	}
+}
+
+func TestBatchAuth(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		var reqs []request
+		if err := json.Unmarshal(b, &reqs); err != nil {
+			// single call for version detection
+			var req request
+			json.Unmarshal(b, &req)
+			fmt.Fprintf(w, `{"jsonrpc":"2.0","result":"6.4.0","id":%d}`, req.ID)
+			return
+		}
+		if h := r.Header.Get("Authorization"); h != "Bearer secret" {
+			t.Errorf("Expected auth in header, got %q", h)
+		}
+		for _, req := range reqs {
+			if req.Auth != "" {
+				t.Errorf("%s: expected no auth in body, got %q", req.Method, req.Auth)
+			}
+		}
+		fmt.Fprintf(w, `[{"jsonrpc":"2.0","result":"6.4.0","id":%d},{"jsonrpc":"2.0","result":[],"id":%d}]`, reqs[0].ID, reqs[1].ID)
+	}))
+	defer srv.Close()
+
+	batch := NewAPIWithToken(srv.URL, "secret").NewBatch()
+	c1 := batch.Add("APIInfo.version", Params{}, nil)
+	c2 := batch.Add("host.get", Params{}, nil)
+	if err := batch.Send(); err != nil {
+		t.Fatal(err)
+	}
+	if c1.Err != nil || c2.Err != nil {
+		t.Errorf("Unexpected errors: %v, %v", c1.Err, c2.Err)
+	}
+
+	// without header auth anonymous calls still get no auth in body
+	api := NewAPI(srv.URL)
+	api.SetAuth("session")
+	api.AuthMode = AuthBody
+	reqs := make([]request, 0, 2)
+	api.SetClient(&http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		json.NewDecoder(req.Body).Decode(&reqs)
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": {"application/json"}},
+			Body:       ioutil.NopCloser(strings.NewReader("[]")),
+		}, nil
+	})})
+	batch = api.NewBatch()
+	batch.Add("APIInfo.version", Params{}, nil)
+	batch.Add("host.get", Params{}, nil)
+	batch.Send()
+	if len(reqs) != 2 || reqs[0].Auth != "" || reqs[1].Auth != "session" {
+		t.Errorf("Bad body auth: %+v", reqs)
+	}
+}
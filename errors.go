@@ -0,0 +1,101 @@
+package zabbix
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+)
+
+// Sentinel errors which API errors match with errors.Is():
+//
+//	if errors.Is(err, zabbix.ErrAlreadyExists) { ... }
+//
+// They are derived from code and data of *Error, and one error may match several of them.
+// Zabbix reports missing and inaccessible objects with the same message, so such errors
+// match both ErrNotFound and ErrPermissionDenied.
+var (
+	ErrNotFound         = errors.New("Object not found.")
+	ErrAlreadyExists    = errors.New("Object already exists.")
+	ErrPermissionDenied = errors.New("Permission denied.")
+	ErrSessionExpired   = errors.New("Session expired.")
+	ErrInvalidParams    = errors.New("Invalid params.")
+)
+
+// Error codes defined by JSON-RPC 2.0 and used by Zabbix
+const (
+	CodeParseError     = -32700
+	CodeInvalidRequest = -32600
+	CodeMethodNotFound = -32601
+	CodeInvalidParams  = -32602
+	CodeInternalError  = -32603
+	CodeAppError       = -32500
+)
+
+// Is - Matches e with sentinel errors, see ErrNotFound and others.
+func (e *Error) Is(target error) bool {
+	data := strings.ToLower(e.Data)
+	switch target {
+	case ErrNotFound:
+		// missing API method is not a missing object
+		return e.Code != CodeMethodNotFound && (strings.Contains(data, "does not exist") || strings.Contains(data, "not found"))
+	case ErrAlreadyExists:
+		return strings.Contains(data, "already exist")
+	case ErrPermissionDenied:
+		return strings.Contains(data, "no permission") || strings.Contains(data, "not have permission") ||
+			strings.Contains(data, "permission denied")
+	case ErrSessionExpired:
+		return IsSessionExpired(e)
+	case ErrInvalidParams:
+		return e.Code == CodeInvalidParams
+	}
+	return false
+}
+
+// Is - Matches ErrNotFound if there were no results.
+func (e *ExpectedOneResult) Is(target error) bool {
+	return target == ErrNotFound && *e == 0
+}
+
+// maxSnippet - max length of response body kept in HTTPError
+const maxSnippet = 512
+
+// HTTPError - HTTP response which is not JSON-RPC one, like 502 page of reverse proxy or HTML login form
+type HTTPError struct {
+	StatusCode  int
+	Status      string // like "502 Bad Gateway"
+	ContentType string
+	Body        string // beginning of response body
+}
+
+// snippet - Returns s cut to maxSnippet bytes, without splitting UTF-8 sequence.
+func snippet(s string) string {
+	if len(s) <= maxSnippet {
+		return s
+	}
+	i := maxSnippet
+	for i > 0 && !utf8.RuneStart(s[i]) {
+		i--
+	}
+	return s[:i] + "..."
+}
+
+func newHTTPError(res *http.Response, b []byte) *HTTPError {
+	return &HTTPError{
+		StatusCode:  res.StatusCode,
+		Status:      res.Status,
+		ContentType: res.Header.Get("Content-Type"),
+		Body:        snippet(string(b)),
+	}
+}
+
+// Error - error interface impl
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("Unexpected HTTP response %s (%s): %s", e.Status, e.ContentType, e.Body)
+}
+
+// Is - Matches ErrPermissionDenied for 401 and 403 status codes.
+func (e *HTTPError) Is(target error) bool {
+	return target == ErrPermissionDenied && (e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden)
+}
@@ -0,0 +1,76 @@
+package zabbix
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestErrorIs(t *testing.T) {
+	for _, c := range []struct {
+		err      error
+		expected []error
+	}{
+		{&Error{-32602, "Invalid params.", `Host with the same name "test" already exists.`}, []error{ErrAlreadyExists, ErrInvalidParams}},
+		{&Error{-32500, "Application error.", "No permissions to referred object or it does not exist!"}, []error{ErrNotFound, ErrPermissionDenied}},
+		{&Error{-32602, "Invalid params.", "Session terminated, re-login, please."}, []error{ErrSessionExpired, ErrInvalidParams}},
+		{&Error{-32500, "Application error.", "Not authorized."}, []error{ErrSessionExpired}},
+		{&Error{-32601, "Method not found.", `Method "application.get" not found.`}, nil},
+		{fmt.Errorf("wrapped: %w", &Error{-32602, "Invalid params.", `Incorrect value for field "name".`}), []error{ErrInvalidParams}},
+		{new(ExpectedOneResult), []error{ErrNotFound}},
+	} {
+		for _, sentinel := range []error{ErrNotFound, ErrAlreadyExists, ErrPermissionDenied, ErrSessionExpired, ErrInvalidParams} {
+			expected := false
+			for _, e := range c.expected {
+				expected = expected || e == sentinel
+			}
+			if actual := errors.Is(c.err, sentinel); actual != expected {
+				t.Errorf("errors.Is(%q, %q): expected %v, got %v", c.err, sentinel, expected, actual)
+			}
+		}
+	}
+}
+
+func TestHTTPError(t *testing.T) {
+	for _, c := range []struct {
+		status      int
+		contentType string
+		body        string
+		ok          bool
+	}{
+		{http.StatusBadGateway, "text/html", "<html>502 Bad Gateway</html>", false},
+		{http.StatusUnauthorized, "text/plain", "Unauthorized", false},
+		{http.StatusOK, "text/html; charset=utf-8", "<html>" + strings.Repeat("login ", 200) + "</html>", false},
+		{http.StatusOK, "text/html", "a" + strings.Repeat("я", maxSnippet), false}, // maxSnippet splits 2-byte rune
+		{http.StatusOK, "application/json", `{"jsonrpc":"2.0","result":"3.0.0","id":1}`, true},
+	} {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", c.contentType)
+			w.WriteHeader(c.status)
+			fmt.Fprint(w, c.body)
+		}))
+		_, err := NewAPI(srv.URL).Call("host.get", Params{})
+		srv.Close()
+
+		if c.ok {
+			if err != nil {
+				t.Errorf("%d: unexpected error %v", c.status, err)
+			}
+			continue
+		}
+		var e *HTTPError
+		if !errors.As(err, &e) {
+			t.Fatalf("%d: expected *HTTPError, got %v", c.status, err)
+		}
+		if e.StatusCode != c.status || len(e.Body) > maxSnippet+3 || !utf8.ValidString(e.Body) || !strings.HasPrefix(c.body, strings.TrimSuffix(e.Body, "...")) {
+			t.Errorf("%d: bad error %#v", c.status, e)
+		}
+		if errors.Is(err, ErrPermissionDenied) != (c.status == http.StatusUnauthorized) {
+			t.Errorf("%d: bad errors.Is(ErrPermissionDenied)", c.status)
+		}
+	}
+}
@@ -0,0 +1,262 @@
+package zabbix
+
+import (
+	"context"
+	"fmt"
+)
+
+type (
+	// AcknowledgeAction - event.acknowledge action bitmask
+	AcknowledgeAction int
+)
+
+const (
+	// AckClose - close problem
+	AckClose AcknowledgeAction = 1
+	// AckAcknowledge - acknowledge event
+	AckAcknowledge AcknowledgeAction = 2
+	// AckMessage - add message
+	AckMessage AcknowledgeAction = 4
+	// AckSeverity - change severity
+	AckSeverity AcknowledgeAction = 8
+	// AckUnacknowledge - unacknowledge event, 5.0+
+	AckUnacknowledge AcknowledgeAction = 16
+	// AckSuppress - suppress event, 6.4+
+	AckSuppress AcknowledgeAction = 32
+	// AckUnsuppress - unsuppress event, 6.4+
+	AckUnsuppress AcknowledgeAction = 64
+)
+
+// UnmarshalJSON - json.Unmarshaler interface impl, accepts both number and string
+func (t *AcknowledgeAction) UnmarshalJSON(b []byte) error {
+	return unmarshalInt(b, (*int)(t))
+}
+
+// Acknowledge - event update made by user, returned with selectAcknowledges
+type Acknowledge struct {
+	ID          string            `json:"acknowledgeid"`
+	UserID      string            `json:"userid"`
+	EventID     string            `json:"eventid"`
+	Clock       string            `json:"clock"`
+	Message     string            `json:"message"`
+	Action      AcknowledgeAction `json:"action"`
+	OldSeverity SeverityType      `json:"old_severity"` // 4.0+
+	NewSeverity SeverityType      `json:"new_severity"` // 4.0+
+}
+
+// Acknowledges - the array of Acknowledge
+type Acknowledges []Acknowledge
+
+// SuppressionData - maintenance suppressing event, returned with selectSuppressionData
+type SuppressionData struct {
+	MaintenanceID string `json:"maintenanceid"`
+	SuppressUntil string `json:"suppress_until"` // 0 - indefinitely
+}
+
+// Problem - https://www.zabbix.com/documentation/4.0/manual/api/reference/problem/object
+type Problem struct {
+	EventID       string       `json:"eventid"`
+	Source        Int          `json:"source"` // 0 - trigger, 3 - internal
+	Object        Int          `json:"object"` // 0 - trigger, 4 - item, 5 - LLD rule
+	ObjectID      string       `json:"objectid"`
+	Clock         string       `json:"clock"`
+	NS            string       `json:"ns"`
+	REventID      string       `json:"r_eventid"` // recovery event Id, "0" if problem is not resolved
+	RClock        string       `json:"r_clock"`
+	CorrelationID string       `json:"correlationid"`
+	UserID        string       `json:"userid"` // user who closed problem
+	Name          string       `json:"name"`
+	Acknowledged  Int          `json:"acknowledged"`
+	Severity      SeverityType `json:"severity"`
+	Suppressed    Int          `json:"suppressed"` // 4.0+
+	Opdata        string       `json:"opdata"`     // 5.0+
+
+	Tags            Tags              `json:"tags"`
+	Acknowledges    Acknowledges      `json:"acknowledges"`
+	SuppressionData []SuppressionData `json:"suppression_data"` // 4.0+
+}
+
+// Problems - the array of Problem
+type Problems []Problem
+
+// problems - Returns Resource for problem methods.
+func (api *API) problems() *Resource[Problem] {
+	return NewResource(api, "problem", "eventid", func(p *Problem) *string { return &p.EventID })
+}
+
+// Event - https://www.zabbix.com/documentation/4.0/manual/api/reference/event/object
+type Event struct {
+	EventID       string           `json:"eventid"`
+	Source        Int              `json:"source"` // 0 - trigger, 1 - discovery, 2 - autoregistration, 3 - internal
+	Object        Int              `json:"object"` // 0 - trigger, 4 - item, 5 - LLD rule for internal events
+	ObjectID      string           `json:"objectid"`
+	Clock         string           `json:"clock"`
+	NS            string           `json:"ns"`
+	Value         TriggerValueType `json:"value"`
+	REventID      string           `json:"r_eventid"` // recovery event Id
+	CEventID      string           `json:"c_eventid"` // event correlation Id
+	CorrelationID string           `json:"correlationid"`
+	UserID        string           `json:"userid"`
+	Name          string           `json:"name"`
+	Acknowledged  Int              `json:"acknowledged"`
+	Severity      SeverityType     `json:"severity"`
+	Suppressed    Int              `json:"suppressed"` // 4.0+
+	Opdata        string           `json:"opdata"`     // 5.0+
+
+	Tags            Tags              `json:"tags"`
+	Acknowledges    Acknowledges      `json:"acknowledges"`
+	SuppressionData []SuppressionData `json:"suppression_data"` // 4.0+
+}
+
+// Events - the array of Event
+type Events []Event
+
+// events - Returns Resource for event methods.
+func (api *API) events() *Resource[Event] {
+	return NewResource(api, "event", "eventid", func(e *Event) *string { return &e.EventID })
+}
+
+// EventAcknowledge - event.acknowledge params
+type EventAcknowledge struct {
+	EventIds []string
+	Action   AcknowledgeAction
+	Message  string       // used with AckMessage
+	Severity SeverityType // used with AckSeverity
+
+	SuppressUntil int64 // Unix time used with AckSuppress, 0 - indefinitely
+}
+
+// ackActions - Returns acknowledge actions supported by server with capabilities c.
+func ackActions(c Capabilities) (res AcknowledgeAction) {
+	if !c.AckActions {
+		res = AckAcknowledge | AckMessage
+		if c.AckClose {
+			res |= AckClose
+		}
+		return
+	}
+	res = AckClose | AckAcknowledge | AckMessage | AckSeverity
+	if c.AckUnack {
+		res |= AckUnacknowledge
+	}
+	if c.AckSuppress {
+		res |= AckSuppress | AckUnsuppress
+	}
+	return
+}
+
+// params - Returns event.acknowledge params for server with capabilities c: fields are sent only if required by action.
+// Before 4.0 there is no action bitmask: events are always acknowledged with message, and 3.4 takes action 1 to close problem.
+func (ack EventAcknowledge) params(c Capabilities) (params Params, err error) {
+	if unsupported := ack.Action &^ ackActions(c); unsupported != 0 {
+		err = fmt.Errorf("Acknowledge action %d is not supported by server.", unsupported)
+		return
+	}
+	if !c.AckActions {
+		params = Params{"eventids": ack.EventIds, "message": ack.Message}
+		if ack.Action&AckClose != 0 {
+			params["action"] = 1
+		}
+		return
+	}
+
+	params = Params{"eventids": ack.EventIds, "action": ack.Action}
+	if ack.Action&AckMessage != 0 {
+		params["message"] = ack.Message
+	}
+	if ack.Action&AckSeverity != 0 {
+		params["severity"] = ack.Severity
+	}
+	if ack.Action&AckSuppress != 0 {
+		params["suppress_until"] = ack.SuppressUntil
+	}
+	return
+}
+
+// selectDetails - Adds selectAcknowledges, selectTags and selectSuppressionData to params if they are not present.
+// Tags and suppression data are selected only if server is known to support them.
+func (api *API) selectDetails(ctx context.Context, params Params) {
+	caps, capsErr := api.CapabilitiesContext(ctx)
+	for key, supported := range map[string]bool{
+		"selectAcknowledges":    true,
+		"selectTags":            capsErr == nil && caps.TriggerRecovery,
+		"selectSuppressionData": capsErr == nil && caps.Suppression,
+	} {
+		if _, present := params[key]; !present && supported {
+			params[key] = "extend"
+		}
+	}
+}
+
+// ProblemsGet - Wrapper for problem.get: https://www.zabbix.com/documentation/4.0/manual/api/reference/problem/get
+// Tags, acknowledges and suppression data are selected unless params specify otherwise.
+func (api *API) ProblemsGet(params Params) (res Problems, err error) {
+	return api.ProblemsGetContext(context.Background(), params)
+}
+
+// ProblemsGetContext - Same as ProblemsGet, but bound to ctx.
+func (api *API) ProblemsGetContext(ctx context.Context, params Params) (res Problems, err error) {
+	api.selectDetails(ctx, params)
+	res, err = api.problems().GetContext(ctx, params)
+	return
+}
+
+// EventsGet - Wrapper for event.get: https://www.zabbix.com/documentation/4.0/manual/api/reference/event/get
+// Tags, acknowledges and suppression data are selected unless params specify otherwise.
+func (api *API) EventsGet(params Params) (res Events, err error) {
+	return api.EventsGetContext(context.Background(), params)
+}
+
+// EventsGetContext - Same as EventsGet, but bound to ctx.
+func (api *API) EventsGetContext(ctx context.Context, params Params) (res Events, err error) {
+	api.selectDetails(ctx, params)
+	res, err = api.events().GetContext(ctx, params)
+	return
+}
+
+// EventGetByID - Gets event by Id only if there is exactly 1 matching event.
+func (api *API) EventGetByID(id string) (res *Event, err error) {
+	return api.EventGetByIDContext(context.Background(), id)
+}
+
+// EventGetByIDContext - Same as EventGetByID, but bound to ctx.
+func (api *API) EventGetByIDContext(ctx context.Context, id string) (res *Event, err error) {
+	params := Params{"eventids": id}
+	api.selectDetails(ctx, params)
+	return api.events().GetOneContext(ctx, params)
+}
+
+// EventsAcknowledge - Wrapper for event.acknowledge: https://www.zabbix.com/documentation/4.0/manual/api/reference/event/acknowledge
+// Action is a combination of AckClose, AckAcknowledge, AckMessage, AckSeverity, AckUnacknowledge, AckSuppress and AckUnsuppress.
+// Error is returned for actions not supported by server version; before 4.0 only AckAcknowledge, AckMessage
+// and AckClose (3.4+) are supported.
+func (api *API) EventsAcknowledge(ack EventAcknowledge) (err error) {
+	return api.EventsAcknowledgeContext(context.Background(), ack)
+}
+
+// EventsAcknowledgeContext - Same as EventsAcknowledge, but bound to ctx.
+func (api *API) EventsAcknowledgeContext(ctx context.Context, ack EventAcknowledge) (err error) {
+	caps, capsErr := api.CapabilitiesContext(ctx)
+	if capsErr != nil {
+		// version is unknown, action is sent as is
+		caps = Capabilities{AckClose: true, AckActions: true, AckUnack: true, AckSuppress: true}
+	}
+	params, err := ack.params(caps)
+	if err != nil {
+		return
+	}
+
+	response, err := api.callRaw(ctx, "event.acknowledge", params)
+	if err != nil {
+		return
+	}
+
+	eventids, err := resultIds("event.acknowledge", response.Result, "eventids")
+	if err != nil {
+		return
+	}
+	if len(eventids) != len(ack.EventIds) {
+		err = &ExpectedMore{len(ack.EventIds), len(eventids)}
+	}
+	return
+}
@@ -0,0 +1,112 @@
+package zabbix
+
+import (
+	"testing"
+)
+
+func TestProblemsGet(t *testing.T) {
+	api, calls := newMethodAPI(map[string]string{
+		"problem.get": `[{"eventid":"10","source":"0","object":"0","objectid":"7","clock":"1500000000","name":"CPU is high",
+			"acknowledged":"1","severity":"4","suppressed":"1",
+			"tags":[{"tag":"service","value":"web"}],
+			"acknowledges":[{"acknowledgeid":"3","userid":"1","eventid":"10","clock":"1500000100","message":"on it","action":"6","old_severity":"0","new_severity":"0"}],
+			"suppression_data":[{"maintenanceid":"2","suppress_until":"0"}]}]`,
+	})
+	api.SetVersion(ServerVersion{4, 0, 0})
+
+	problems, err := api.ProblemsGet(NewQuery().Set("recent", true).Params())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p := string((*calls)[0].Params); p != `{"output":"extend","recent":true,"selectAcknowledges":"extend","selectSuppressionData":"extend","selectTags":"extend"}` {
+		t.Errorf("unexpected params %s", p)
+	}
+	if len(problems) != 1 {
+		t.Fatalf("expected 1 problem, got %d", len(problems))
+	}
+	p := problems[0]
+	if p.Severity != SeverityHigh || p.Acknowledged != 1 || p.Suppressed != 1 || p.Tags[0].Value != "web" ||
+		p.SuppressionData[0].MaintenanceID != "2" {
+		t.Errorf("bad problem %#v", p)
+	}
+	if a := p.Acknowledges[0]; a.Action != AckAcknowledge|AckMessage || a.Message != "on it" {
+		t.Errorf("bad acknowledge %#v", a)
+	}
+
+	// tags and suppression data are not supported by 3.0
+	api, calls = newMethodAPI(map[string]string{"event.get": `[]`})
+	if _, err = api.EventsGet(Params{}); err != nil {
+		t.Fatal(err)
+	}
+	if p := string((*calls)[0].Params); p != `{"output":"extend","selectAcknowledges":"extend"}` {
+		t.Errorf("unexpected params %s", p)
+	}
+}
+
+func TestEventsAcknowledge(t *testing.T) {
+	for expected, ack := range map[string]EventAcknowledge{
+		`{"action":6,"eventids":["1","2"],"message":"on it"}`: {
+			EventIds: []string{"1", "2"}, Action: AckAcknowledge | AckMessage, Message: "on it", Severity: SeverityHigh,
+		},
+		`{"action":9,"eventids":["1","2"],"severity":0}`: {
+			EventIds: []string{"1", "2"}, Action: AckClose | AckSeverity, Message: "ignored", Severity: SeverityNotClassified,
+		},
+		`{"action":32,"eventids":["1","2"],"suppress_until":0}`: {
+			EventIds: []string{"1", "2"}, Action: AckSuppress,
+		},
+	} {
+		api, calls := newMethodAPI(map[string]string{"event.acknowledge": `{"eventids":[1,2]}`})
+		api.SetVersion(ServerVersion{6, 4, 0})
+		if err := api.EventsAcknowledge(ack); err != nil {
+			t.Fatal(err)
+		}
+		if p := string((*calls)[0].Params); p != expected {
+			t.Errorf("expected %s, got %s", expected, p)
+		}
+	}
+
+	// before 3.4 events are acknowledged with message only
+	api, calls := newMethodAPI(map[string]string{"event.acknowledge": `{"eventids":[1,2]}`})
+	ack := EventAcknowledge{EventIds: []string{"1", "2"}, Action: AckAcknowledge | AckMessage, Message: "on it"}
+	if err := api.EventsAcknowledge(ack); err != nil {
+		t.Fatal(err)
+	}
+	if p, expected := string((*calls)[0].Params), `{"eventids":["1","2"],"message":"on it"}`; p != expected {
+		t.Errorf("expected %s, got %s", expected, p)
+	}
+
+	// 3.4 closes problem with action 1
+	api, calls = newMethodAPI(map[string]string{"event.acknowledge": `{"eventids":[1,2]}`})
+	api.SetVersion(ServerVersion{3, 4, 0})
+	ack = EventAcknowledge{EventIds: []string{"1", "2"}, Action: AckAcknowledge | AckMessage | AckClose, Message: "fixed"}
+	if err := api.EventsAcknowledge(ack); err != nil {
+		t.Fatal(err)
+	}
+	if p, expected := string((*calls)[0].Params), `{"action":1,"eventids":["1","2"],"message":"fixed"}`; p != expected {
+		t.Errorf("expected %s, got %s", expected, p)
+	}
+
+	for _, c := range []struct {
+		version ServerVersion
+		action  AcknowledgeAction
+	}{
+		{ServerVersion{3, 2, 0}, AckClose},
+		{ServerVersion{3, 4, 0}, AckSeverity},
+		{ServerVersion{4, 0, 0}, AckUnacknowledge},
+		{ServerVersion{5, 0, 0}, AckAcknowledge | AckSuppress},
+		{ServerVersion{6, 0, 0}, AckUnsuppress},
+	} {
+		api, calls := newMethodAPI(map[string]string{"event.acknowledge": `{"eventids":[1]}`})
+		api.SetVersion(c.version)
+		err := api.EventsAcknowledge(EventAcknowledge{EventIds: []string{"1"}, Action: c.action})
+		if err == nil || len(*calls) != 0 {
+			t.Errorf("%s: expected error for action %d without call, got %v", c.version, c.action, err)
+		}
+	}
+
+	api, _ = newMethodAPI(map[string]string{"event.acknowledge": `{"eventids":[1]}`})
+	err := api.EventsAcknowledge(EventAcknowledge{EventIds: []string{"1", "2"}, Action: AckAcknowledge})
+	if _, ok := err.(*ExpectedMore); !ok {
+		t.Errorf("expected ExpectedMore, got %v", err)
+	}
+}
@@ -0,0 +1,3 @@
+module github.com/zssky/zabbix
+
+go 1.21
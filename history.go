@@ -1,6 +1,9 @@
 package zabbix
 
-import "github.com/AlekSi/reflector"
+import (
+	"context"
+	"fmt"
+)
 
 // History history data
 type History struct {
@@ -20,14 +23,77 @@ type Historys []History
 
 // HistorysGet Wrapper for History.get: https://www.zabbix.com/documentation/3.0/manual/api/reference/history/get
 func (api *API) HistorysGet(params Params) (res Historys, err error) {
+	return api.HistorysGetContext(context.Background(), params)
+}
+
+// HistorysGetContext - Same as HistorysGet, but bound to ctx.
+func (api *API) HistorysGetContext(ctx context.Context, params Params) (res Historys, err error) {
 	if _, present := params["output"]; !present {
 		params["output"] = "extend"
 	}
-	response, err := api.CallWithError("history.get", params)
+	response, err := api.callRaw(ctx, "history.get", params)
+	if err != nil {
+		return
+	}
+
+	err = decodeResult("history.get", response.Result, &res)
+	return
+}
+
+// HistorysGetEach - Same as HistorysGet, but passes history records to fn one by one as they are read from response,
+// so memory usage does not depend on number of records. If fn returns error, call is stopped and error is returned.
+func (api *API) HistorysGetEach(params Params, fn func(h *History) error) (err error) {
+	return api.HistorysGetEachContext(context.Background(), params, fn)
+}
+
+// HistorysGetEachContext - Same as HistorysGetEach, but bound to ctx.
+func (api *API) HistorysGetEachContext(ctx context.Context, params Params, fn func(h *History) error) (err error) {
+	return streamEach(ctx, api, "history.get", params, fn)
+}
+
+// HistoryValue - value sent by HistoryPush, item is identified by ItemID or by Host and Key
+type HistoryValue struct {
+	ItemID string      `json:"itemid,omitempty"`
+	Host   string      `json:"host,omitempty"`
+	Key    string      `json:"key,omitempty"`
+	Value  interface{} `json:"value"`
+	Clock  int64       `json:"clock,omitempty"` // Unix time, current time if 0
+	NS     int         `json:"ns,omitempty"`
+}
+
+// HistoryPush - Wrapper for history.push, 7.0+: https://www.zabbix.com/documentation/7.0/manual/api/reference/history/push
+// Items should be of HTTP agent or trapper type. If some values are rejected, error for the first of them is returned.
+// Error matching errors.ErrUnsupported is returned for older versions.
+func (api *API) HistoryPush(values []HistoryValue) (err error) {
+	return api.HistoryPushContext(context.Background(), values)
+}
+
+// HistoryPushContext - Same as HistoryPush, but bound to ctx.
+func (api *API) HistoryPushContext(ctx context.Context, values []HistoryValue) (err error) {
+	if err = api.require(ctx, "history.push", func(c Capabilities) bool { return c.HistoryPush }); err != nil {
+		return
+	}
+	response, err := api.callRaw(ctx, "history.push", values)
 	if err != nil {
 		return
 	}
 
-	reflector.MapsToStructs2(response.Result.([]interface{}), &res, reflector.Strconv, "json")
+	var res struct {
+		Data []struct {
+			ItemID string `json:"itemid"`
+			Error  string `json:"error"`
+		} `json:"data"`
+	}
+	if err = decodeResult("history.push", response.Result, &res); err != nil {
+		return
+	}
+	if len(res.Data) != len(values) {
+		return &ExpectedMore{len(values), len(res.Data)}
+	}
+	for i, d := range res.Data {
+		if d.Error != "" {
+			return fmt.Errorf("History value %d was not accepted (%s).", i, d.Error)
+		}
+	}
 	return
 }
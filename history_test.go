@@ -0,0 +1,30 @@
+package zabbix
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestHistoryPush(t *testing.T) {
+	values := []HistoryValue{{ItemID: "10600", Value: 1.5, Clock: 1700000000}, {Host: "server", Key: "trap", Value: "text"}}
+
+	api, calls := newMethodAPI(map[string]string{"history.push": `{"response":"success","data":[{"itemid":"10600"},{"itemid":"10601"}]}`})
+	if err := api.HistoryPush(values); !errors.Is(err, errors.ErrUnsupported) || len(*calls) != 0 {
+		t.Errorf("Expected ErrUnsupported without call for 3.0, got %v", err)
+	}
+
+	api.SetVersion(ServerVersion{7, 0, 0})
+	if err := api.HistoryPush(values); err != nil {
+		t.Fatal(err)
+	}
+	expected := `[{"itemid":"10600","value":1.5,"clock":1700000000},{"host":"server","key":"trap","value":"text"}]`
+	if p := string((*calls)[0].Params); p != expected {
+		t.Errorf("expected %s\ngot      %s", expected, p)
+	}
+
+	api, _ = newMethodAPI(map[string]string{"history.push": `{"response":"success","data":[{"itemid":"10600"},{"error":"Item is disabled."}]}`})
+	api.SetVersion(ServerVersion{7, 0, 0})
+	if err := api.HistoryPush(values); err == nil || err.Error() != "History value 1 was not accepted (Item is disabled.)." {
+		t.Errorf("Expected error for second value, got %v", err)
+	}
+}
@@ -1,7 +1,7 @@
 package zabbix
 
 import (
-	"github.com/AlekSi/reflector"
+	"context"
 )
 
 type (
@@ -23,6 +23,16 @@ const (
 	Unmonitored StatusType = 1
 )
 
+// UnmarshalJSON - json.Unmarshaler interface impl, accepts both number and string
+func (t *AvailableType) UnmarshalJSON(b []byte) error {
+	return unmarshalInt(b, (*int)(t))
+}
+
+// UnmarshalJSON - json.Unmarshaler interface impl, accepts both number and string
+func (t *StatusType) UnmarshalJSON(b []byte) error {
+	return unmarshalInt(b, (*int)(t))
+}
+
 // Host - https://www.zabbix.com/documentation/2.2/manual/appendix/api/host/definitions
 type Host struct {
 	ID        string        `json:"hostid,omitempty"`
@@ -32,130 +42,145 @@ type Host struct {
 	Name      string        `json:"name"`
 	Status    StatusType    `json:"status"`
 
+	Tags Tags `json:"tags,omitempty"` // 4.2+
+
 	// Fields below used only when creating hosts
 	GroupIds   HostGroupIds   `json:"groups,omitempty"`
 	Interfaces HostInterfaces `json:"interfaces,omitempty"`
 	Templates  Templates      `json:"templates,omitempty"`
+	Macros     UserMacros     `json:"macros,omitempty"`
+
+	// Fields below used only when updating hosts
+	TemplatesClear TemplateIds `json:"templates_clear,omitempty"` // templates to unlink and clear
 }
 
 // Hosts - host array
 type Hosts []Host
 
-// HostsGet Wrapper for host.get: https://www.zabbix.com/documentation/2.2/manual/appendix/api/host/get
-func (api *API) HostsGet(params Params) (res Hosts, err error) {
-	if _, present := params["output"]; !present {
-		params["output"] = "extend"
+// params - Returns hosts as create/update params for server with capabilities c: removed and read-only fields are omitted,
+// nested interfaces and macros are shaped too.
+func (hosts Hosts) params(c Capabilities) (res []map[string]interface{}, err error) {
+	fields := []string{"available", "error"}
+	if !c.HostTags {
+		fields = append(fields, "tags")
 	}
-	response, err := api.CallWithError("host.get", params)
-	if err != nil {
+	if res, err = withoutFields(hosts, fields...); err != nil {
+		return
+	}
+	if err = withNested(res, "interfaces", func(i int) ([]map[string]interface{}, error) { return hosts[i].Interfaces.params(c) }); err != nil {
 		return
 	}
+	err = withNested(res, "macros", func(i int) ([]map[string]interface{}, error) { return macroParams(hosts[i].Macros, c) })
+	return
+}
+
+// hosts - Returns Resource for host methods.
+func (api *API) hosts() *Resource[Host] {
+	r := NewResource(api, "host", "hostid", func(h *Host) *string { return &h.ID })
+	r.Params = func(hosts []Host, c Capabilities) ([]map[string]interface{}, error) { return Hosts(hosts).params(c) }
+	return r
+}
 
-	reflector.MapsToStructs2(response.Result.([]interface{}), &res, reflector.Strconv, "json")
+// HostsGet Wrapper for host.get: https://www.zabbix.com/documentation/2.2/manual/appendix/api/host/get
+func (api *API) HostsGet(params Params) (res Hosts, err error) {
+	return api.HostsGetContext(context.Background(), params)
+}
+
+// HostsGetContext - Same as HostsGet, but bound to ctx.
+func (api *API) HostsGetContext(ctx context.Context, params Params) (res Hosts, err error) {
+	res, err = api.hosts().GetContext(ctx, params)
 	return
 }
 
 // HostsGetByHostGroupIds - Gets hosts by host group Ids.
 func (api *API) HostsGetByHostGroupIds(ids []string) (res Hosts, err error) {
-	return api.HostsGet(Params{"groupids": ids})
+	return api.HostsGetByHostGroupIdsContext(context.Background(), ids)
+}
+
+// HostsGetByHostGroupIdsContext - Same as HostsGetByHostGroupIds, but bound to ctx.
+func (api *API) HostsGetByHostGroupIdsContext(ctx context.Context, ids []string) (res Hosts, err error) {
+	return api.HostsGetContext(ctx, Params{"groupids": ids})
 }
 
 // HostsGetByHostGroups -Gets hosts by host groups.
 func (api *API) HostsGetByHostGroups(hostGroups HostGroups) (res Hosts, err error) {
+	return api.HostsGetByHostGroupsContext(context.Background(), hostGroups)
+}
+
+// HostsGetByHostGroupsContext - Same as HostsGetByHostGroups, but bound to ctx.
+func (api *API) HostsGetByHostGroupsContext(ctx context.Context, hostGroups HostGroups) (res Hosts, err error) {
 	ids := make([]string, len(hostGroups))
 	for i, group := range hostGroups {
 		ids[i] = group.ID
 	}
-	return api.HostsGetByHostGroupIds(ids)
+	return api.HostsGetByHostGroupIdsContext(ctx, ids)
 }
 
 // HostGetByID - Gets host by Id only if there is exactly 1 matching host.
 func (api *API) HostGetByID(id string) (res *Host, err error) {
-	hosts, err := api.HostsGet(Params{"hostids": id})
-	if err != nil {
-		return
-	}
+	return api.HostGetByIDContext(context.Background(), id)
+}
 
-	if len(hosts) == 1 {
-		res = &hosts[0]
-	} else {
-		e := ExpectedOneResult(len(hosts))
-		err = &e
-	}
-	return
+// HostGetByIDContext - Same as HostGetByID, but bound to ctx.
+func (api *API) HostGetByIDContext(ctx context.Context, id string) (res *Host, err error) {
+	return api.hosts().GetByIDContext(ctx, id)
 }
 
 // HostGetByHost - Gets host by Host only if there is exactly 1 matching host.
 func (api *API) HostGetByHost(host string) (res *Host, err error) {
-	hosts, err := api.HostsGet(Params{"filter": map[string]string{"host": host}})
-	if err != nil {
-		return
-	}
+	return api.HostGetByHostContext(context.Background(), host)
+}
 
-	if len(hosts) == 1 {
-		res = &hosts[0]
-	} else {
-		e := ExpectedOneResult(len(hosts))
-		err = &e
-	}
-	return
+// HostGetByHostContext - Same as HostGetByHost, but bound to ctx.
+func (api *API) HostGetByHostContext(ctx context.Context, host string) (res *Host, err error) {
+	return api.hosts().GetOneContext(ctx, Params{"filter": map[string]string{"host": host}})
 }
 
 // HostGetByIP - Gets host by ip only if there is exactly 1 matching host.
 func (api *API) HostGetByIP(ip string) (res *Host, err error) {
-	hosts, err := api.HostsGet(Params{"filter": map[string]string{"ip": ip}})
-	if err != nil {
-		return
-	}
+	return api.HostGetByIPContext(context.Background(), ip)
+}
 
-	if len(hosts) == 1 {
-		res = &hosts[0]
-	} else {
-		e := ExpectedOneResult(len(hosts))
-		err = &e
-	}
-	return
+// HostGetByIPContext - Same as HostGetByIP, but bound to ctx.
+func (api *API) HostGetByIPContext(ctx context.Context, ip string) (res *Host, err error) {
+	return api.hosts().GetOneContext(ctx, Params{"filter": map[string]string{"ip": ip}})
 }
 
 // HostsCreate - Wrapper for host.create: https://www.zabbix.com/documentation/2.2/manual/appendix/api/host/create
+// Fields not supported by server version are omitted; if version is unknown, hosts are sent as is.
 func (api *API) HostsCreate(hosts Hosts) (err error) {
-	response, err := api.CallWithError("host.create", hosts)
-	if err != nil {
-		return
-	}
+	return api.HostsCreateContext(context.Background(), hosts)
+}
 
-	result := response.Result.(map[string]interface{})
-	hostids := result["hostids"].([]interface{})
-	for i, id := range hostids {
-		hosts[i].ID = id.(string)
-	}
-	return
+// HostsCreateContext - Same as HostsCreate, but bound to ctx.
+func (api *API) HostsCreateContext(ctx context.Context, hosts Hosts) (err error) {
+	return api.hosts().CreateContext(ctx, hosts)
 }
 
 // HostsUpdate - Wrapper for host.update: https://www.zabbix.com/documentation/3.0/manual/api/reference/host/update
 func (api *API) HostsUpdate(hosts Hosts) (err error) {
-	response, err := api.CallWithError("host.update", hosts)
-	if err != nil {
-		return
-	}
+	return api.HostsUpdateContext(context.Background(), hosts)
+}
 
-	result := response.Result.(map[string]interface{})
-	hostids := result["hostids"].([]interface{})
-	for i, id := range hostids {
-		hosts[i].ID = id.(string)
-	}
-	return
+// HostsUpdateContext - Same as HostsUpdate, but bound to ctx.
+func (api *API) HostsUpdateContext(ctx context.Context, hosts Hosts) (err error) {
+	return api.hosts().UpdateContext(ctx, hosts)
 }
 
 // HostsDelete -Wrapper for host.delete: https://www.zabbix.com/documentation/2.2/manual/appendix/api/host/delete
 // Cleans HostId in all hosts elements if call succeed.
 func (api *API) HostsDelete(hosts Hosts) (err error) {
+	return api.HostsDeleteContext(context.Background(), hosts)
+}
+
+// HostsDeleteContext - Same as HostsDelete, but bound to ctx.
+func (api *API) HostsDeleteContext(ctx context.Context, hosts Hosts) (err error) {
 	ids := make([]string, len(hosts))
 	for i, host := range hosts {
 		ids[i] = host.ID
 	}
 
-	err = api.HostsDeleteByIds(ids)
+	err = api.HostsDeleteByIdsContext(ctx, ids)
 	if err == nil {
 		for i := range hosts {
 			hosts[i].ID = ""
@@ -166,24 +191,33 @@ func (api *API) HostsDelete(hosts Hosts) (err error) {
 
 // HostsDeleteByIds - Wrapper for host.delete: https://www.zabbix.com/documentation/2.2/manual/appendix/api/host/delete
 func (api *API) HostsDeleteByIds(ids []string) (err error) {
-	hostIds := make([]map[string]string, len(ids))
-	for i, id := range ids {
-		hostIds[i] = map[string]string{"hostid": id}
+	return api.HostsDeleteByIdsContext(context.Background(), ids)
+}
+
+// HostsDeleteByIdsContext - Same as HostsDeleteByIds, but bound to ctx.
+func (api *API) HostsDeleteByIdsContext(ctx context.Context, ids []string) (err error) {
+	// Zabbix 2.4+ takes array of Ids, older versions - array of objects
+	var params interface{} = ids
+	caps, capsErr := api.CapabilitiesContext(ctx)
+	if capsErr != nil || !caps.HostDeleteIds {
+		params = idObjects("hostid", ids)
 	}
 
-	response, err := api.CallWithError("host.delete", hostIds)
-	if err != nil {
-		// Zabbix 2.4 uses new syntax only
-		if e, ok := err.(*Error); ok && e.Code == -32500 {
-			response, err = api.CallWithError("host.delete", ids)
+	response, err := api.callRaw(ctx, "host.delete", params)
+	if err != nil && capsErr != nil {
+		// version is unknown, and Zabbix 2.4 uses new syntax only
+		if e, ok := err.(*Error); ok && e.Code == CodeAppError {
+			response, err = api.callRaw(ctx, "host.delete", ids)
 		}
 	}
 	if err != nil {
 		return
 	}
 
-	result := response.Result.(map[string]interface{})
-	hostids := result["hostids"].([]interface{})
+	hostids, err := resultIds("host.delete", response.Result, "hostids")
+	if err != nil {
+		return
+	}
 	if len(ids) != len(hostids) {
 		err = &ExpectedMore{len(ids), len(hostids)}
 	}
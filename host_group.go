@@ -1,7 +1,7 @@
 package zabbix
 
 import (
-	"github.com/AlekSi/reflector"
+	"context"
 )
 
 type (
@@ -16,6 +16,11 @@ const (
 	Internal InternalType = 1
 )
 
+// UnmarshalJSON - json.Unmarshaler interface impl, accepts both number and string
+func (t *InternalType) UnmarshalJSON(b []byte) error {
+	return unmarshalInt(b, (*int)(t))
+}
+
 // HostGroup - https://www.zabbix.com/documentation/2.2/manual/appendix/api/hostgroup/definitions
 type HostGroup struct {
 	ID       string       `json:"groupid,omitempty"`
@@ -26,6 +31,11 @@ type HostGroup struct {
 // HostGroups - the array of HostGroup
 type HostGroups []HostGroup
 
+// hostGroups - Returns Resource for hostgroup methods.
+func (api *API) hostGroups() *Resource[HostGroup] {
+	return NewResource(api, "hostgroup", "groupid", func(g *HostGroup) *string { return &g.ID })
+}
+
 // HostGroupID - host group id
 type HostGroupID struct {
 	GroupID string `json:"groupid"`
@@ -36,77 +46,52 @@ type HostGroupIds []HostGroupID
 
 // HostGroupsGet - Wrapper for hostgroup.get: https://www.zabbix.com/documentation/2.2/manual/appendix/api/hostgroup/get
 func (api *API) HostGroupsGet(params Params) (res HostGroups, err error) {
-	if _, present := params["output"]; !present {
-		params["output"] = "extend"
-	}
-	response, err := api.CallWithError("hostgroup.get", params)
-	if err != nil {
-		return
-	}
-
-	reflector.MapsToStructs2(response.Result.([]interface{}), &res, reflector.Strconv, "json")
+	return api.HostGroupsGetContext(context.Background(), params)
+}
+
+// HostGroupsGetContext - Same as HostGroupsGet, but bound to ctx.
+func (api *API) HostGroupsGetContext(ctx context.Context, params Params) (res HostGroups, err error) {
+	res, err = api.hostGroups().GetContext(ctx, params)
 	return
 }
 
 // HostGroupGetByID - Gets host group by Id only if there is exactly 1 matching host group.
 func (api *API) HostGroupGetByID(id string) (res *HostGroup, err error) {
-	groups, err := api.HostGroupsGet(Params{"groupids": id})
-	if err != nil {
-		return
-	}
-
-	if len(groups) == 1 {
-		res = &groups[0]
-	} else {
-		e := ExpectedOneResult(len(groups))
-		err = &e
-	}
-	return
+	return api.HostGroupGetByIDContext(context.Background(), id)
+}
+
+// HostGroupGetByIDContext - Same as HostGroupGetByID, but bound to ctx.
+func (api *API) HostGroupGetByIDContext(ctx context.Context, id string) (res *HostGroup, err error) {
+	return api.hostGroups().GetByIDContext(ctx, id)
 }
 
 // HostGroupsCreate - Wrapper for hostgroup.create: https://www.zabbix.com/documentation/2.2/manual/appendix/api/hostgroup/create
 func (api *API) HostGroupsCreate(hostGroups HostGroups) (err error) {
-	response, err := api.CallWithError("hostgroup.create", hostGroups)
-	if err != nil {
-		return
-	}
-
-	result := response.Result.(map[string]interface{})
-	groupids := result["groupids"].([]interface{})
-	for i, id := range groupids {
-		hostGroups[i].ID = id.(string)
-	}
-	return
+	return api.HostGroupsCreateContext(context.Background(), hostGroups)
+}
+
+// HostGroupsCreateContext - Same as HostGroupsCreate, but bound to ctx.
+func (api *API) HostGroupsCreateContext(ctx context.Context, hostGroups HostGroups) (err error) {
+	return api.hostGroups().CreateContext(ctx, hostGroups)
 }
 
 // HostGroupsDelete - Wrapper for hostgroup.delete: https://www.zabbix.com/documentation/2.2/manual/appendix/api/hostgroup/delete
 // Cleans GroupId in all hostGroups elements if call succeed.
 func (api *API) HostGroupsDelete(hostGroups HostGroups) (err error) {
-	ids := make([]string, len(hostGroups))
-	for i, group := range hostGroups {
-		ids[i] = group.ID
-	}
-
-	err = api.HostGroupsDeleteByIds(ids)
-	if err == nil {
-		for i := range hostGroups {
-			hostGroups[i].ID = ""
-		}
-	}
-	return
+	return api.HostGroupsDeleteContext(context.Background(), hostGroups)
+}
+
+// HostGroupsDeleteContext - Same as HostGroupsDelete, but bound to ctx.
+func (api *API) HostGroupsDeleteContext(ctx context.Context, hostGroups HostGroups) (err error) {
+	return api.hostGroups().DeleteContext(ctx, hostGroups)
 }
 
 // HostGroupsDeleteByIds - Wrapper for hostgroup.delete: https://www.zabbix.com/documentation/2.2/manual/appendix/api/hostgroup/delete
 func (api *API) HostGroupsDeleteByIds(ids []string) (err error) {
-	response, err := api.CallWithError("hostgroup.delete", ids)
-	if err != nil {
-		return
-	}
-
-	result := response.Result.(map[string]interface{})
-	groupids := result["groupids"].([]interface{})
-	if len(ids) != len(groupids) {
-		err = &ExpectedMore{len(ids), len(groupids)}
-	}
-	return
+	return api.HostGroupsDeleteByIdsContext(context.Background(), ids)
+}
+
+// HostGroupsDeleteByIdsContext - Same as HostGroupsDeleteByIds, but bound to ctx.
+func (api *API) HostGroupsDeleteByIdsContext(ctx context.Context, ids []string) (err error) {
+	return api.hostGroups().DeleteByIdsContext(ctx, ids)
 }
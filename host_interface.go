@@ -1,5 +1,11 @@
 package zabbix
 
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+)
+
 type (
 	// InterfaceType - interface type
 	InterfaceType int
@@ -16,15 +22,223 @@ const (
 	JMX InterfaceType = 4
 )
 
+// UnmarshalJSON - json.Unmarshaler interface impl, accepts both number and string
+func (t *InterfaceType) UnmarshalJSON(b []byte) error {
+	return unmarshalInt(b, (*int)(t))
+}
+
+// HostInterfaceDetails - SNMP interface details, 5.0+: https://www.zabbix.com/documentation/5.0/manual/api/reference/hostinterface/object#details_tag
+type HostInterfaceDetails struct {
+	Version Int `json:"version"` // 1, 2 or 3
+	Bulk    Int `json:"bulk"`    // 1 - use bulk requests
+
+	// SNMPv1 and SNMPv2 fields
+	Community string `json:"community,omitempty"`
+
+	// SNMPv3 fields
+	SecurityName   string `json:"securityname,omitempty"`
+	SecurityLevel  Int    `json:"securitylevel,omitempty"` // 0 - noAuthNoPriv, 1 - authNoPriv, 2 - authPriv
+	AuthPassphrase string `json:"authpassphrase,omitempty"`
+	PrivPassphrase string `json:"privpassphrase,omitempty"`
+	AuthProtocol   Int    `json:"authprotocol,omitempty"` // 0 - MD5, 1 - SHA1
+	PrivProtocol   Int    `json:"privprotocol,omitempty"` // 0 - DES, 1 - AES128
+	ContextName    string `json:"contextname,omitempty"`
+}
+
+// UnmarshalJSON - json.Unmarshaler interface impl, accepts empty array returned for non-SNMP interfaces
+func (d *HostInterfaceDetails) UnmarshalJSON(b []byte) error {
+	if bytes.Equal(bytes.TrimSpace(b), []byte("[]")) {
+		*d = HostInterfaceDetails{}
+		return nil
+	}
+	type details HostInterfaceDetails
+	return json.Unmarshal(b, (*details)(d))
+}
+
 // HostInterface - https://www.zabbix.com/documentation/2.2/manual/appendix/api/hostinterface/definitions
 type HostInterface struct {
-	DNS   string        `json:"dns"`
-	IP    string        `json:"ip"`
-	Main  int           `json:"main"`
-	Port  string        `json:"port"`
-	Type  InterfaceType `json:"type"`
-	UseIP int           `json:"useip"`
+	ID     string        `json:"interfaceid,omitempty"`
+	HostID string        `json:"hostid,omitempty"` // not used when creating hosts
+	DNS    string        `json:"dns"`
+	IP     string        `json:"ip"`
+	Main   Int           `json:"main"`
+	Port   string        `json:"port"`
+	Type   InterfaceType `json:"type"`
+	UseIP  Int           `json:"useip"`
+
+	Details *HostInterfaceDetails `json:"details,omitempty"` // SNMP interfaces only, 5.0+
+
+	// Fields below are read-only, 5.4+
+	Available AvailableType `json:"available,omitempty"`
+	Error     string        `json:"error,omitempty"`
 }
 
 // HostInterfaces - host interface
 type HostInterfaces []HostInterface
+
+// Main - Returns main interface of type t, or nil if there is none.
+func (interfaces HostInterfaces) Main(t InterfaceType) *HostInterface {
+	for i := range interfaces {
+		if interfaces[i].Type == t && interfaces[i].Main == 1 {
+			return &interfaces[i]
+		}
+	}
+	return nil
+}
+
+// params - Returns interfaces as create/update params for server with capabilities c: removed and read-only fields are omitted,
+// details are sent for SNMP interfaces only, as server returns empty details for other ones.
+func (interfaces HostInterfaces) params(c Capabilities) (res []map[string]interface{}, err error) {
+	fields := []string{"available", "error"}
+	if !c.SNMPDetails {
+		fields = append(fields, "details")
+	}
+	if res, err = withoutFields(interfaces, fields...); err != nil {
+		return
+	}
+	for i := range interfaces {
+		if interfaces[i].Type != SNMP {
+			delete(res[i], "details")
+		}
+	}
+	return
+}
+
+// hostInterfaces - Returns Resource for hostinterface methods.
+func (api *API) hostInterfaces() *Resource[HostInterface] {
+	r := NewResource(api, "hostinterface", "interfaceid", func(i *HostInterface) *string { return &i.ID })
+	r.Params = func(interfaces []HostInterface, c Capabilities) ([]map[string]interface{}, error) {
+		return HostInterfaces(interfaces).params(c)
+	}
+	return r
+}
+
+// HostInterfacesGet - Wrapper for hostinterface.get: https://www.zabbix.com/documentation/3.0/manual/api/reference/hostinterface/get
+func (api *API) HostInterfacesGet(params Params) (res HostInterfaces, err error) {
+	return api.HostInterfacesGetContext(context.Background(), params)
+}
+
+// HostInterfacesGetContext - Same as HostInterfacesGet, but bound to ctx.
+func (api *API) HostInterfacesGetContext(ctx context.Context, params Params) (res HostInterfaces, err error) {
+	res, err = api.hostInterfaces().GetContext(ctx, params)
+	return
+}
+
+// HostInterfacesGetByHostIds - Gets interfaces of hosts.
+func (api *API) HostInterfacesGetByHostIds(ids []string) (res HostInterfaces, err error) {
+	return api.HostInterfacesGetByHostIdsContext(context.Background(), ids)
+}
+
+// HostInterfacesGetByHostIdsContext - Same as HostInterfacesGetByHostIds, but bound to ctx.
+func (api *API) HostInterfacesGetByHostIdsContext(ctx context.Context, ids []string) (res HostInterfaces, err error) {
+	return api.HostInterfacesGetContext(ctx, Params{"hostids": ids})
+}
+
+// HostInterfaceGetByID - Gets interface by Id only if there is exactly 1 matching interface.
+func (api *API) HostInterfaceGetByID(id string) (res *HostInterface, err error) {
+	return api.HostInterfaceGetByIDContext(context.Background(), id)
+}
+
+// HostInterfaceGetByIDContext - Same as HostInterfaceGetByID, but bound to ctx.
+func (api *API) HostInterfaceGetByIDContext(ctx context.Context, id string) (res *HostInterface, err error) {
+	return api.hostInterfaces().GetByIDContext(ctx, id)
+}
+
+// HostInterfacesCreate - Wrapper for hostinterface.create: https://www.zabbix.com/documentation/3.0/manual/api/reference/hostinterface/create
+// Fields not supported by server version are omitted; if version is unknown, interfaces are sent as is.
+func (api *API) HostInterfacesCreate(interfaces HostInterfaces) (err error) {
+	return api.HostInterfacesCreateContext(context.Background(), interfaces)
+}
+
+// HostInterfacesCreateContext - Same as HostInterfacesCreate, but bound to ctx.
+func (api *API) HostInterfacesCreateContext(ctx context.Context, interfaces HostInterfaces) (err error) {
+	return api.hostInterfaces().CreateContext(ctx, interfaces)
+}
+
+// HostInterfacesUpdate - Wrapper for hostinterface.update: https://www.zabbix.com/documentation/3.0/manual/api/reference/hostinterface/update
+// Fields not supported by server version are omitted; if version is unknown, interfaces are sent as is.
+func (api *API) HostInterfacesUpdate(interfaces HostInterfaces) (err error) {
+	return api.HostInterfacesUpdateContext(context.Background(), interfaces)
+}
+
+// HostInterfacesUpdateContext - Same as HostInterfacesUpdate, but bound to ctx.
+func (api *API) HostInterfacesUpdateContext(ctx context.Context, interfaces HostInterfaces) (err error) {
+	return api.hostInterfaces().UpdateContext(ctx, interfaces)
+}
+
+// HostInterfacesDelete - Wrapper for hostinterface.delete: https://www.zabbix.com/documentation/3.0/manual/api/reference/hostinterface/delete
+// Cleans ID in all interfaces elements if call succeed.
+func (api *API) HostInterfacesDelete(interfaces HostInterfaces) (err error) {
+	return api.HostInterfacesDeleteContext(context.Background(), interfaces)
+}
+
+// HostInterfacesDeleteContext - Same as HostInterfacesDelete, but bound to ctx.
+func (api *API) HostInterfacesDeleteContext(ctx context.Context, interfaces HostInterfaces) (err error) {
+	return api.hostInterfaces().DeleteContext(ctx, interfaces)
+}
+
+// HostInterfacesDeleteByIds - Wrapper for hostinterface.delete: https://www.zabbix.com/documentation/3.0/manual/api/reference/hostinterface/delete
+func (api *API) HostInterfacesDeleteByIds(ids []string) (err error) {
+	return api.HostInterfacesDeleteByIdsContext(context.Background(), ids)
+}
+
+// HostInterfacesDeleteByIdsContext - Same as HostInterfacesDeleteByIds, but bound to ctx.
+func (api *API) HostInterfacesDeleteByIdsContext(ctx context.Context, ids []string) (err error) {
+	return api.hostInterfaces().DeleteByIdsContext(ctx, ids)
+}
+
+// interfacesParams - Returns interfaces shaped for server version, or as is if version is unknown.
+func (api *API) interfacesParams(ctx context.Context, interfaces HostInterfaces) (params interface{}, err error) {
+	params = interfaces
+	if caps, e := api.CapabilitiesContext(ctx); e == nil {
+		params, err = interfaces.params(caps)
+	}
+	return
+}
+
+// HostInterfacesMassAdd - Wrapper for hostinterface.massadd: https://www.zabbix.com/documentation/3.0/manual/api/reference/hostinterface/massadd
+// Adds copies of interfaces to every host.
+func (api *API) HostInterfacesMassAdd(hostIds []string, interfaces HostInterfaces) (err error) {
+	return api.HostInterfacesMassAddContext(context.Background(), hostIds, interfaces)
+}
+
+// HostInterfacesMassAddContext - Same as HostInterfacesMassAdd, but bound to ctx.
+func (api *API) HostInterfacesMassAddContext(ctx context.Context, hostIds []string, interfaces HostInterfaces) (err error) {
+	ifaces, err := api.interfacesParams(ctx, interfaces)
+	if err != nil {
+		return
+	}
+	params := Params{"hosts": idObjects("hostid", hostIds), "interfaces": ifaces}
+	return api.massUpdate(ctx, "hostinterface.massadd", params, "interfaceids", len(hostIds)*len(interfaces))
+}
+
+// HostInterfacesReplace - Wrapper for hostinterface.replacehostinterfaces: https://www.zabbix.com/documentation/3.0/manual/api/reference/hostinterface/replacehostinterfaces
+// Replaces all interfaces of host. Sets ID and HostID in all interfaces elements if call succeed.
+func (api *API) HostInterfacesReplace(hostID string, interfaces HostInterfaces) (err error) {
+	return api.HostInterfacesReplaceContext(context.Background(), hostID, interfaces)
+}
+
+// HostInterfacesReplaceContext - Same as HostInterfacesReplace, but bound to ctx.
+func (api *API) HostInterfacesReplaceContext(ctx context.Context, hostID string, interfaces HostInterfaces) (err error) {
+	ifaces, err := api.interfacesParams(ctx, interfaces)
+	if err != nil {
+		return
+	}
+	response, err := api.callRaw(ctx, "hostinterface.replacehostinterfaces", Params{"hostid": hostID, "interfaces": ifaces})
+	if err != nil {
+		return
+	}
+
+	interfaceids, err := resultIds("hostinterface.replacehostinterfaces", response.Result, "interfaceids")
+	if err != nil {
+		return
+	}
+	if len(interfaceids) != len(interfaces) {
+		err = &ExpectedMore{len(interfaces), len(interfaceids)}
+		return
+	}
+	for i, id := range interfaceids {
+		interfaces[i].ID, interfaces[i].HostID = id, hostID
+	}
+	return
+}
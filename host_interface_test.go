@@ -0,0 +1,162 @@
+package zabbix
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestHostInterfaces(t *testing.T) {
+	api := getAPI(t)
+
+	group := CreateHostGroup(t)
+	defer DeleteHostGroup(group, t)
+
+	host := CreateHost(group, t)
+	defer DeleteHost(host, t)
+
+	interfaces, err := api.HostInterfacesGetByHostIds([]string{host.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(interfaces) != 1 || interfaces.Main(Agent) == nil {
+		t.Fatalf("Bad interfaces: %#v", interfaces)
+	}
+
+	jmx := HostInterfaces{{HostID: host.ID, DNS: host.Host, Port: "12345", Type: JMX, Main: 1}}
+	if err = api.HostInterfacesCreate(jmx); err != nil {
+		t.Fatal(err)
+	}
+	jmx[0].Port = "12346"
+	if err = api.HostInterfacesUpdate(jmx); err != nil {
+		t.Fatal(err)
+	}
+	got, err := api.HostInterfaceGetByID(jmx[0].ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Port != "12346" {
+		t.Errorf("Bad port: %s", got.Port)
+	}
+	if err = api.HostInterfacesDelete(jmx); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestHostInterfaceDetails(t *testing.T) {
+	var interfaces HostInterfaces
+	err := json.Unmarshal([]byte(`[
+		{"interfaceid":"1","hostid":"10","type":"1","main":"1","useip":"1","ip":"127.0.0.1","dns":"","port":"10050","details":[]},
+		{"interfaceid":"2","hostid":"10","type":"2","main":"1","useip":"1","ip":"127.0.0.1","dns":"","port":"161",
+			"details":{"version":"3","bulk":"1","securityname":"zabbix","securitylevel":"2","authprotocol":"1","privprotocol":"1"}}
+	]`), &interfaces)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := interfaces[0].Details; d == nil || *d != (HostInterfaceDetails{}) {
+		t.Errorf("Bad agent details: %#v", d)
+	}
+	snmp := interfaces.Main(SNMP)
+	if snmp == nil || snmp.Details.Version != 3 || snmp.Details.SecurityLevel != 2 || snmp.Details.SecurityName != "zabbix" {
+		t.Errorf("Bad SNMP interface: %#v", snmp)
+	}
+	if interfaces.Main(JMX) != nil {
+		t.Error("Unexpected JMX interface")
+	}
+
+	// empty details of agent interface returned by server are not sent back
+	params, err := interfaces.params(ServerVersion{5, 0, 0}.Capabilities())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, present := params[0]["details"]; present {
+		t.Errorf("Details sent for agent interface: %v", params[0])
+	}
+	if _, present := params[1]["details"]; !present {
+		t.Errorf("Details not sent for SNMP interface: %v", params[1])
+	}
+
+	for version, expected := range map[string]string{
+		"4.0.0": `[{"dns":"","ip":"127.0.0.1","main":1,"port":"161","type":2,"useip":1}]`,
+		"5.0.0": `[{"details":{"bulk":1,"community":"public","version":2},"dns":"","ip":"127.0.0.1","main":1,"port":"161","type":2,"useip":1}]`,
+	} {
+		v, _ := ParseVersion(version)
+		ifaces := HostInterfaces{{IP: "127.0.0.1", Port: "161", Type: SNMP, Main: 1, UseIP: 1, Available: Available,
+			Details: &HostInterfaceDetails{Version: 2, Bulk: 1, Community: "public"}}}
+		params, err := ifaces.params(v.Capabilities())
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, _ := json.Marshal(params)
+		if string(b) != expected {
+			t.Errorf("%s:\nexpected %s\ngot      %s", version, expected, b)
+		}
+
+		// interfaces nested in hosts are shaped the same way
+		hosts, err := Hosts{{Host: "h", Interfaces: ifaces}}.params(v.Capabilities())
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, _ = json.Marshal(hosts[0]["interfaces"])
+		if string(b) != expected {
+			t.Errorf("%s host:\nexpected %s\ngot      %s", version, expected, b)
+		}
+	}
+}
+
+func TestHostInterfacesMass(t *testing.T) {
+	api, calls := newMethodAPI(map[string]string{
+		"hostinterface.massadd":               `{"interfaceids":["1","2","3","4"]}`,
+		"hostinterface.replacehostinterfaces": `{"interfaceids":["5","6"]}`,
+	})
+	interfaces := HostInterfaces{{IP: "127.0.0.1", Port: "10050", Type: Agent, Main: 1, UseIP: 1}, {IP: "127.0.0.1", Port: "161", Type: SNMP, Main: 1, UseIP: 1}}
+	if err := api.HostInterfacesMassAdd([]string{"10", "11"}, interfaces); err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"hosts":[{"hostid":"10"},{"hostid":"11"}],"interfaces":[` +
+		`{"dns":"","ip":"127.0.0.1","main":1,"port":"10050","type":1,"useip":1},{"dns":"","ip":"127.0.0.1","main":1,"port":"161","type":2,"useip":1}]}`
+	if p := string((*calls)[0].Params); p != expected {
+		t.Errorf("expected %s\ngot      %s", expected, p)
+	}
+
+	if err := api.HostInterfacesReplace("10", interfaces); err != nil {
+		t.Fatal(err)
+	}
+	if interfaces[0].ID != "5" || interfaces[1].ID != "6" || interfaces[1].HostID != "10" {
+		t.Errorf("Ids are not set: %#v", interfaces)
+	}
+}
+
+func TestItemsCreateInterface(t *testing.T) {
+	api, calls := newMethodAPI(map[string]string{
+		"hostinterface.get": `[{"interfaceid":"1","hostid":"10","type":"1","main":"0"},{"interfaceid":"2","hostid":"10","type":"1","main":"1"},
+			{"interfaceid":"3","hostid":"10","type":"2","main":"1"},{"interfaceid":"4","hostid":"11","type":"1","main":"1"}]`,
+		"item.create": `{"itemids":["1","2","3","4","5"]}`,
+	})
+	items := Items{
+		{HostID: "10", Key: "agent", Type: ZabbixAgent},
+		{HostID: "10", Key: "snmp", Type: SNMPv2Agent},
+		{HostID: "11", Key: "agent", Type: ZabbixAgent},
+		{HostID: "11", Key: "trapper", Type: ZabbixTrapper},
+		{HostID: "11", Key: "bound", Type: ZabbixAgent, InterfaceID: "42"},
+	}
+	if err := api.ItemsCreate(items); err != nil {
+		t.Fatal(err)
+	}
+	if p := string((*calls)[0].Params); p != `{"hostids":["10","11"],"output":"extend"}` {
+		t.Errorf("unexpected hostinterface.get params %s", p)
+	}
+	for i, expected := range []string{"2", "3", "4", "", "42"} {
+		if items[i].InterfaceID != expected {
+			t.Errorf("%s: expected interface %q, got %q", items[i].Key, expected, items[i].InterfaceID)
+		}
+	}
+
+	// no lookup if no item requires interface
+	api, calls = newMethodAPI(map[string]string{"item.create": `{"itemids":["1"]}`})
+	if err := api.ItemsCreate(Items{{HostID: "10", Key: "trapper", Type: ZabbixTrapper}}); err != nil {
+		t.Fatal(err)
+	}
+	if len(*calls) != 1 || (*calls)[0].Method != "item.create" {
+		t.Errorf("unexpected calls %v", *calls)
+	}
+}
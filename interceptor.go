@@ -0,0 +1,25 @@
+package zabbix
+
+import (
+	"context"
+)
+
+// Invoker - makes JSON-RPC call of method with params.
+// err is something network or marshaling related, API error is returned in response.Error.
+type Invoker func(ctx context.Context, method string, params interface{}) (response Response, err error)
+
+// Interceptor - middleware around every JSON-RPC call. It may inspect or replace ctx, method and params,
+// call next (or not call it at all) and inspect or replace response and err.
+// For calls made by wrappers like HostsGet, response.Result is json.RawMessage.
+type Interceptor func(ctx context.Context, method string, params interface{}, next Invoker) (response Response, err error)
+
+// chain - Wraps invoker with api.Interceptors, first of them is the outermost one.
+func (api *API) chain(invoker Invoker) Invoker {
+	for i := len(api.Interceptors) - 1; i >= 0; i-- {
+		interceptor, next := api.Interceptors[i], invoker
+		invoker = func(ctx context.Context, method string, params interface{}) (Response, error) {
+			return interceptor(ctx, method, params, next)
+		}
+	}
+	return invoker
+}
@@ -0,0 +1,51 @@
+package zabbix
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestInterceptors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req request
+		json.NewDecoder(r.Body).Decode(&req)
+		fmt.Fprintf(w, `{"jsonrpc":"2.0","result":%q,"id":%d}`, req.Method, req.ID)
+	}))
+	defer srv.Close()
+
+	var trace []string
+	api := NewAPI(srv.URL)
+	api.Interceptors = []Interceptor{
+		func(ctx context.Context, method string, params interface{}, next Invoker) (Response, error) {
+			trace = append(trace, "outer "+method)
+			res, err := next(ctx, method, params)
+			trace = append(trace, fmt.Sprintf("outer %v", res.Result))
+			return res, err
+		},
+		func(ctx context.Context, method string, params interface{}, next Invoker) (Response, error) {
+			if method == "host.delete" {
+				return Response{Error: &Error{Code: -32500, Message: "Injected."}}, nil
+			}
+			return next(ctx, strings.Replace(method, "host.", "item.", 1), params)
+		},
+	}
+
+	res, err := api.CallWithError("host.get", Params{})
+	if err != nil || res.Result != "item.get" {
+		t.Errorf("Unexpected result: %v %v", res.Result, err)
+	}
+	_, err = api.CallWithError("host.delete", []string{"1"})
+	if e, ok := err.(*Error); !ok || e.Code != -32500 {
+		t.Errorf("Expected injected error, got %v", err)
+	}
+
+	expected := "outer host.get,outer item.get,outer host.delete,outer <nil>"
+	if actual := strings.Join(trace, ","); actual != expected {
+		t.Errorf("Expected %s, got %s", expected, actual)
+	}
+}
@@ -1,9 +1,8 @@
 package zabbix
 
 import (
+	"context"
 	"fmt"
-
-	"github.com/AlekSi/reflector"
 )
 
 type (
@@ -15,6 +14,10 @@ type (
 	DataType int
 	// DeltaType - delta type
 	DeltaType int
+	// PreprocessingType - item preprocessing step type
+	PreprocessingType int
+	// ErrorHandlerType - item preprocessing error handler type
+	ErrorHandlerType int
 )
 
 const (
@@ -54,6 +57,8 @@ const (
 	JMXAgent ItemType = 16
 	// SNMPTrap - SNMP trap
 	SNMPTrap ItemType = 17
+	// SNMPAgent - SNMP agent, 5.0+, replacement of SNMPv1Agent, SNMPv2Agent and SNMPv3Agent
+	SNMPAgent ItemType = 20
 
 	// Float -  numeric float
 	Float ValueType = 0
@@ -81,27 +86,107 @@ const (
 	Speed DeltaType = 1
 	// Delta - Delta, simple change
 	Delta DeltaType = 2
+
+	// PreprocessingMultiplier - custom multiplier
+	PreprocessingMultiplier PreprocessingType = 1
+	// PreprocessingRightTrim - right trim
+	PreprocessingRightTrim PreprocessingType = 2
+	// PreprocessingLeftTrim - left trim
+	PreprocessingLeftTrim PreprocessingType = 3
+	// PreprocessingTrim - trim
+	PreprocessingTrim PreprocessingType = 4
+	// PreprocessingRegex - regular expression
+	PreprocessingRegex PreprocessingType = 5
+	// PreprocessingBooleanToDecimal - boolean to decimal, replacement of Boolean data type
+	PreprocessingBooleanToDecimal PreprocessingType = 6
+	// PreprocessingOctalToDecimal - octal to decimal, replacement of Octal data type
+	PreprocessingOctalToDecimal PreprocessingType = 7
+	// PreprocessingHexToDecimal - hexadecimal to decimal, replacement of Hexadecimal data type
+	PreprocessingHexToDecimal PreprocessingType = 8
+	// PreprocessingSimpleChange - simple change, replacement of Delta
+	PreprocessingSimpleChange PreprocessingType = 9
+	// PreprocessingChangePerSecond - change per second, replacement of Speed
+	PreprocessingChangePerSecond PreprocessingType = 10
+	// PreprocessingXMLPath - XML XPath
+	PreprocessingXMLPath PreprocessingType = 11
+	// PreprocessingJSONPath - JSONPath
+	PreprocessingJSONPath PreprocessingType = 12
+	// PreprocessingJavaScript - JavaScript, 4.2+
+	PreprocessingJavaScript PreprocessingType = 21
+
+	// ErrorHandlerDefault - (default) set value to unsupported
+	ErrorHandlerDefault ErrorHandlerType = 0
+	// ErrorHandlerDiscard - discard value
+	ErrorHandlerDiscard ErrorHandlerType = 1
+	// ErrorHandlerSetValue - set custom value
+	ErrorHandlerSetValue ErrorHandlerType = 2
+	// ErrorHandlerSetError - set custom error message
+	ErrorHandlerSetError ErrorHandlerType = 3
 )
 
+// UnmarshalJSON - json.Unmarshaler interface impl, accepts both number and string
+func (t *ItemType) UnmarshalJSON(b []byte) error {
+	return unmarshalInt(b, (*int)(t))
+}
+
+// UnmarshalJSON - json.Unmarshaler interface impl, accepts both number and string
+func (t *ValueType) UnmarshalJSON(b []byte) error {
+	return unmarshalInt(b, (*int)(t))
+}
+
+// UnmarshalJSON - json.Unmarshaler interface impl, accepts both number and string
+func (t *DataType) UnmarshalJSON(b []byte) error {
+	return unmarshalInt(b, (*int)(t))
+}
+
+// UnmarshalJSON - json.Unmarshaler interface impl, accepts both number and string
+func (t *DeltaType) UnmarshalJSON(b []byte) error {
+	return unmarshalInt(b, (*int)(t))
+}
+
+// UnmarshalJSON - json.Unmarshaler interface impl, accepts both number and string
+func (t *PreprocessingType) UnmarshalJSON(b []byte) error {
+	return unmarshalInt(b, (*int)(t))
+}
+
+// UnmarshalJSON - json.Unmarshaler interface impl, accepts both number and string
+func (t *ErrorHandlerType) UnmarshalJSON(b []byte) error {
+	return unmarshalInt(b, (*int)(t))
+}
+
+// ItemPreprocessing - https://www.zabbix.com/documentation/4.0/manual/api/reference/item/object#item_preprocessing
+type ItemPreprocessing struct {
+	Type               PreprocessingType `json:"type"`
+	Params             string            `json:"params"`
+	ErrorHandler       ErrorHandlerType  `json:"error_handler"`        // 4.0+
+	ErrorHandlerParams string            `json:"error_handler_params"` // 4.0+
+}
+
+// ItemPreprocessings - the array of ItemPreprocessing
+type ItemPreprocessings []ItemPreprocessing
+
 // Item - https://www.zabbix.com/documentation/2.2/manual/appendix/api/item/definitions
 type Item struct {
 	ID          string    `json:"itemid,omitempty"`
-	Delay       int       `json:"delay"`
+	Delay       Interval  `json:"delay"` // 0 for items without polling, like trapper ones
 	HostID      string    `json:"hostid"`
 	InterfaceID string    `json:"interfaceid,omitempty"`
 	Key         string    `json:"key_"`
 	Name        string    `json:"name"`
 	Type        ItemType  `json:"type"`
 	ValueType   ValueType `json:"value_type"`
-	DataType    DataType  `json:"data_type"`
-	Delta       DeltaType `json:"delta"`
+	DataType    DataType  `json:"data_type"` // removed in 3.4, use Preprocessing
+	Delta       DeltaType `json:"delta"`     // removed in 3.4, use Preprocessing
 	Description string    `json:"description"`
 	Error       string    `json:"error"`
-	History     int       `json:"history,omitempty"`
-	Trends      int       `json:"trends,omitempty"`
+	History     Interval  `json:"history,omitempty"`
+	Trends      Interval  `json:"trends,omitempty"`
+
+	Preprocessing ItemPreprocessings `json:"preprocessing,omitempty"` // 3.4+
+	Tags          Tags               `json:"tags,omitempty"`          // 5.4+
 
 	// Fields below used only when creating applications
-	ApplicationIds []string `json:"applications,omitempty"`
+	ApplicationIds []string `json:"applications,omitempty"` // removed in 5.4, use Tags
 }
 
 // Items - the array of item
@@ -120,74 +205,160 @@ func (items Items) ByKey() (res map[string]Item) {
 	return
 }
 
-// ItemsGet - Wrapper for item.get https://www.zabbix.com/documentation/2.2/manual/appendix/api/item/get
-func (api *API) ItemsGet(params Params) (res Items, err error) {
-	if _, present := params["output"]; !present {
-		params["output"] = "extend"
+// params - Returns items as create params for server with capabilities c: removed and read-only fields are omitted,
+// SNMPv1Agent, SNMPv2Agent and SNMPv3Agent types are replaced by SNMPAgent for 5.0+.
+func (items Items) params(c Capabilities) (res []map[string]interface{}, err error) {
+	fields := []string{"error"}
+	if !c.ItemDataType {
+		fields = append(fields, "data_type", "delta")
 	}
-	response, err := api.CallWithError("item.get", params)
-	if err != nil {
+	if !c.Applications {
+		fields = append(fields, "applications")
+	}
+	if !c.Preprocessing {
+		fields = append(fields, "preprocessing")
+	}
+	if !c.ItemTags {
+		fields = append(fields, "tags")
+	}
+	if res, err = withoutFields(items, fields...); err != nil {
 		return
 	}
+	if c.SNMPDetails {
+		// SNMP version is set in interface details instead of item type
+		for i, item := range items {
+			if snmpTypes[item.Type] {
+				res[i]["type"] = SNMPAgent
+			}
+		}
+	}
+	if c.ErrorHandler {
+		return
+	}
+
+	for _, item := range res {
+		steps, _ := item["preprocessing"].([]interface{})
+		for _, step := range steps {
+			if m, ok := step.(map[string]interface{}); ok {
+				delete(m, "error_handler")
+				delete(m, "error_handler_params")
+			}
+		}
+	}
+	return
+}
+
+// items - Returns Resource for item methods.
+func (api *API) items() *Resource[Item] {
+	r := NewResource(api, "item", "itemid", func(i *Item) *string { return &i.ID })
+	r.Params = func(items []Item, c Capabilities) ([]map[string]interface{}, error) { return Items(items).params(c) }
+	return r
+}
+
+// ItemsGet - Wrapper for item.get https://www.zabbix.com/documentation/2.2/manual/appendix/api/item/get
+func (api *API) ItemsGet(params Params) (res Items, err error) {
+	return api.ItemsGetContext(context.Background(), params)
+}
 
-	reflector.MapsToStructs2(response.Result.([]interface{}), &res, reflector.Strconv, "json")
+// ItemsGetContext - Same as ItemsGet, but bound to ctx.
+func (api *API) ItemsGetContext(ctx context.Context, params Params) (res Items, err error) {
+	res, err = api.items().GetContext(ctx, params)
 	return
 }
 
-// ItemsGetByApplicationID - Gets items by application Id.
+// ItemsGetByApplicationID - Gets items by application Id. Error matching errors.ErrUnsupported is returned for Zabbix 5.4+.
 func (api *API) ItemsGetByApplicationID(id string) (res Items, err error) {
-	return api.ItemsGet(Params{"applicationids": id})
+	return api.ItemsGetByApplicationIDContext(context.Background(), id)
 }
 
-// ItemsCreate - Wrapper for item.create: https://www.zabbix.com/documentation/2.2/manual/appendix/api/item/create
-func (api *API) ItemsCreate(items Items) (err error) {
-	response, err := api.CallWithError("item.create", items)
-	if err != nil {
+// ItemsGetByApplicationIDContext - Same as ItemsGetByApplicationID, but bound to ctx.
+func (api *API) ItemsGetByApplicationIDContext(ctx context.Context, id string) (res Items, err error) {
+	if err = api.requireApplications(ctx); err != nil {
+		return
+	}
+	return api.ItemsGetContext(ctx, Params{"applicationids": id})
+}
+
+// snmpTypes - item types replaced by SNMPAgent in 5.0
+var snmpTypes = map[ItemType]bool{SNMPv1Agent: true, SNMPv2Agent: true, SNMPv3Agent: true}
+
+// interfaceTypes - interface types required by item types
+var interfaceTypes = map[ItemType]InterfaceType{
+	ZabbixAgent: Agent,
+	SNMPv1Agent: SNMP,
+	SNMPv2Agent: SNMP,
+	SNMPv3Agent: SNMP,
+	SNMPAgent:   SNMP,
+	SNMPTrap:    SNMP,
+	IPMIAgent:   IPMI,
+	JMXAgent:    JMX,
+}
+
+// fillInterfaces - Sets InterfaceID of items which require interface, but have none, to main interface of matching type
+// of item host. Interfaces of all such hosts are fetched with single call.
+func (api *API) fillInterfaces(ctx context.Context, items Items) (err error) {
+	var hostIds []string
+	seen := make(map[string]bool)
+	for _, item := range items {
+		if _, required := interfaceTypes[item.Type]; required && item.InterfaceID == "" && !seen[item.HostID] {
+			seen[item.HostID] = true
+			hostIds = append(hostIds, item.HostID)
+		}
+	}
+	if len(hostIds) == 0 {
 		return
 	}
 
-	result := response.Result.(map[string]interface{})
-	itemids := result["itemids"].([]interface{})
-	for i, id := range itemids {
-		items[i].ID = id.(string)
+	interfaces, err := api.HostInterfacesGetByHostIdsContext(ctx, hostIds)
+	if err != nil {
+		return
+	}
+	byHost := make(map[string]HostInterfaces)
+	for _, iface := range interfaces {
+		byHost[iface.HostID] = append(byHost[iface.HostID], iface)
+	}
+	for i, item := range items {
+		if t, required := interfaceTypes[item.Type]; required && item.InterfaceID == "" {
+			if iface := byHost[item.HostID].Main(t); iface != nil {
+				items[i].InterfaceID = iface.ID
+			}
+		}
 	}
 	return
 }
 
+// ItemsCreate - Wrapper for item.create: https://www.zabbix.com/documentation/2.2/manual/appendix/api/item/create
+// Fields not supported by server version are omitted; if version is unknown, items are sent as is.
+// Items which require interface, but have no InterfaceID, are bound to main interface of matching type of their host.
+func (api *API) ItemsCreate(items Items) (err error) {
+	return api.ItemsCreateContext(context.Background(), items)
+}
+
+// ItemsCreateContext - Same as ItemsCreate, but bound to ctx.
+func (api *API) ItemsCreateContext(ctx context.Context, items Items) (err error) {
+	if err = api.fillInterfaces(ctx, items); err != nil {
+		return
+	}
+	return api.items().CreateContext(ctx, items)
+}
+
 // ItemsDelete - Wrapper for item.delete: https://www.zabbix.com/documentation/2.2/manual/appendix/api/item/delete
 // Cleans ItemId in all items elements if call succeed.
 func (api *API) ItemsDelete(items Items) (err error) {
-	ids := make([]string, len(items))
-	for i, item := range items {
-		ids[i] = item.ID
-	}
+	return api.ItemsDeleteContext(context.Background(), items)
+}
 
-	err = api.ItemsDeleteByIds(ids)
-	if err == nil {
-		for i := range items {
-			items[i].ID = ""
-		}
-	}
-	return
+// ItemsDeleteContext - Same as ItemsDelete, but bound to ctx.
+func (api *API) ItemsDeleteContext(ctx context.Context, items Items) (err error) {
+	return api.items().DeleteContext(ctx, items)
 }
 
 // ItemsDeleteByIds - Wrapper for item.delete: https://www.zabbix.com/documentation/2.2/manual/appendix/api/item/delete
 func (api *API) ItemsDeleteByIds(ids []string) (err error) {
-	response, err := api.CallWithError("item.delete", ids)
-	if err != nil {
-		return
-	}
+	return api.ItemsDeleteByIdsContext(context.Background(), ids)
+}
 
-	result := response.Result.(map[string]interface{})
-	itemids1, ok := result["itemids"].([]interface{})
-	l := len(itemids1)
-	if !ok {
-		// some versions actually return map there
-		itemids2 := result["itemids"].(map[string]interface{})
-		l = len(itemids2)
-	}
-	if len(ids) != l {
-		err = &ExpectedMore{len(ids), l}
-	}
-	return
+// ItemsDeleteByIdsContext - Same as ItemsDeleteByIds, but bound to ctx.
+func (api *API) ItemsDeleteByIdsContext(ctx context.Context, ids []string) (err error) {
+	return api.items().DeleteByIdsContext(ctx, ids)
 }
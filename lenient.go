@@ -0,0 +1,96 @@
+package zabbix
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// Int - int which is decoded from both JSON number and string, as Zabbix returns most numbers as strings
+type Int int
+
+// UnmarshalJSON - json.Unmarshaler interface impl
+func (i *Int) UnmarshalJSON(b []byte) error {
+	return unmarshalInt(b, (*int)(i))
+}
+
+// Interval - time period: "30s", "90d" or "{$MACRO}" for Zabbix 3.4+, number of seconds (days for history and trends)
+// for older versions. It is decoded from both JSON number and string. Empty interval is encoded as 0.
+// Integer values used before Interval was introduced may be converted with NewInterval and Seconds.
+type Interval string
+
+// intervalUnits - seconds in interval suffixes
+var intervalUnits = map[byte]int{'s': 1, 'm': 60, 'h': 3600, 'd': 86400, 'w': 604800}
+
+// NewInterval - Returns interval of n seconds (days for history and trends before Zabbix 3.4).
+func NewInterval(n int) Interval {
+	return Interval(strconv.Itoa(n))
+}
+
+// Seconds - Returns interval in seconds. Number without suffix is returned as is, so history and trends
+// of Zabbix before 3.4 are returned in days. Error is returned for macros and flexible intervals.
+func (i Interval) Seconds() (res int, err error) {
+	s, unit := string(i), 1
+	if s == "" {
+		return
+	}
+	if u, ok := intervalUnits[s[len(s)-1]]; ok {
+		s, unit = s[:len(s)-1], u
+	}
+	if res, err = strconv.Atoi(s); err != nil {
+		err = fmt.Errorf("Expected interval in seconds, got %q.", string(i))
+		return
+	}
+	res *= unit
+	return
+}
+
+// MarshalJSON - json.Marshaler interface impl, empty interval is encoded as 0
+func (i Interval) MarshalJSON() ([]byte, error) {
+	if i == "" {
+		return []byte("0"), nil
+	}
+	return json.Marshal(string(i))
+}
+
+// UnmarshalJSON - json.Unmarshaler interface impl
+func (i *Interval) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		return nil
+	}
+	if len(b) > 0 && b[0] == '"' {
+		return json.Unmarshal(b, (*string)(i))
+	}
+
+	var n json.Number
+	if err := json.Unmarshal(b, &n); err != nil {
+		return fmt.Errorf("Expected interval, got %s.", b)
+	}
+	*i = Interval(n)
+	return nil
+}
+
+// unmarshalInt - Decodes JSON number or string with number into v. Empty string and null are decoded as 0.
+func unmarshalInt(b []byte, v *int) (err error) {
+	s := string(b)
+	if s == "null" {
+		*v = 0
+		return
+	}
+	if len(b) > 0 && b[0] == '"' {
+		if err = json.Unmarshal(b, &s); err != nil {
+			return
+		}
+		if s == "" {
+			*v = 0
+			return
+		}
+	}
+
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return fmt.Errorf("Expected integer, got %s.", b)
+	}
+	*v = n
+	return
+}
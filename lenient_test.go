@@ -0,0 +1,66 @@
+package zabbix
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTypedDecoding(t *testing.T) {
+	api := newResultAPI(`[{"itemid":"23970","hostid":"10084","key_":"system.cpu.load","type":"0","value_type":0,` +
+		`"delay":"30s","history":7,"trends":"365d","tags":[{"tag":"component","value":"cpu"}]}]`)
+	items, err := api.ItemsGet(Params{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := Items{{
+		ID: "23970", HostID: "10084", Key: "system.cpu.load", Type: ZabbixAgent, ValueType: Float,
+		Delay: "30s", History: "7", Trends: "365d", Tags: Tags{{Tag: "component", Value: "cpu"}},
+	}}
+	if !reflect.DeepEqual(items, expected) {
+		t.Errorf("Expected %#v, got %#v", expected, items)
+	}
+
+	api = newResultAPI(`[{"hostid":"10084","host":"server","available":"1","status":"","interfaces":[{"main":"1","useip":1,"type":"2"}]}]`)
+	hosts, err := api.HostsGet(Params{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h := hosts[0]; h.Available != Available || h.Status != Monitored || h.Interfaces[0].Main != 1 || h.Interfaces[0].Type != SNMP {
+		t.Errorf("Unexpected host %#v", h)
+	}
+
+	api = newResultAPI(`[{"hostid":"10084","available":"yes"}]`)
+	var e *UnexpectedResult
+	if _, err = api.HostsGet(Params{}); !errors.As(err, &e) {
+		t.Errorf("Expected *UnexpectedResult, got %v", err)
+	}
+}
+
+func TestInterval(t *testing.T) {
+	for i, expected := range map[Interval]int{"": 0, "30": 30, "30s": 30, "5m": 300, "1h": 3600, "90d": 7776000, "1w": 604800} {
+		if n, err := i.Seconds(); err != nil || n != expected {
+			t.Errorf("%q: expected %d, got %d (%v)", i, expected, n, err)
+		}
+	}
+	for _, i := range []Interval{"{$DELAY}", "30s;50s/1-5,09:00-18:00", "s"} {
+		if _, err := i.Seconds(); err == nil {
+			t.Errorf("%q: expected error", i)
+		}
+	}
+	if i := NewInterval(60); i != "60" {
+		t.Errorf("Expected 60, got %q", i)
+	}
+
+	// delay is always sent, like when it was int
+	b, _ := json.Marshal(Item{Key: "trap", Type: ZabbixTrapper})
+	if !strings.Contains(string(b), `"delay":0,`) || strings.Contains(string(b), "history") {
+		t.Errorf("Unexpected item %s", b)
+	}
+	b, _ = json.Marshal(Item{Delay: "30s", History: NewInterval(7)})
+	if !strings.Contains(string(b), `"delay":"30s",`) || !strings.Contains(string(b), `"history":"7"`) {
+		t.Errorf("Unexpected item %s", b)
+	}
+}
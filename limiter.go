@@ -0,0 +1,108 @@
+package zabbix
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Limiter - token bucket rate limiter with optional cap of concurrent calls.
+// It is safe for concurrent use and may be shared between several API objects.
+type Limiter struct {
+	rate  float64 // tokens per second, 0 means no rate limit
+	burst float64 // bucket size
+	sem   chan struct{}
+
+	m      sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// NewLimiter - Creates new limiter which allows rate calls per second with bursts up to burst calls,
+// and at most maxInFlight concurrent calls. Zero rate or maxInFlight means no corresponding limit.
+func NewLimiter(rate float64, burst, maxInFlight int) (l *Limiter) {
+	if burst < 1 {
+		burst = 1
+	}
+	l = &Limiter{rate: rate, burst: float64(burst), tokens: float64(burst)}
+	if maxInFlight > 0 {
+		l.sem = make(chan struct{}, maxInFlight)
+	}
+	return
+}
+
+// Acquire - Waits until call is allowed or ctx is done. If err is nil, release must be called when call is finished.
+// Rate token is taken before in-flight slot, so calls waiting for rate do not hold slots.
+func (l *Limiter) Acquire(ctx context.Context) (release func(), err error) {
+	if err = l.wait(ctx); err != nil {
+		return nil, err
+	}
+
+	release = func() {}
+	if l.sem != nil {
+		select {
+		case l.sem <- struct{}{}:
+			release = func() { <-l.sem }
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	return
+}
+
+// wait - Takes token from bucket, waiting for it if necessary.
+func (l *Limiter) wait(ctx context.Context) error {
+	if l.rate <= 0 {
+		return nil
+	}
+
+	l.m.Lock()
+	now := time.Now()
+	if !l.last.IsZero() {
+		l.tokens += now.Sub(l.last).Seconds() * l.rate
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+	}
+	l.last = now
+	l.tokens--
+	delay := time.Duration(-l.tokens / l.rate * float64(time.Second))
+	l.m.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+	if err := sleepContext(ctx, delay); err != nil {
+		// return reserved token
+		l.m.Lock()
+		l.tokens++
+		l.m.Unlock()
+		return err
+	}
+	return nil
+}
+
+// acquire - Acquires limiter for method from api.MethodLimiters, if any, and then api.Limiter.
+// Method limiter goes first, so calls waiting for it do not hold api.Limiter and do not block other methods.
+func (api *API) acquire(ctx context.Context, method string) (release func(), err error) {
+	release = func() {}
+	if l := api.MethodLimiters[method]; l != nil {
+		if release, err = l.Acquire(ctx); err != nil {
+			return
+		}
+	}
+
+	if api.Limiter != nil {
+		var releaseAPI func()
+		if releaseAPI, err = api.Limiter.Acquire(ctx); err != nil {
+			release()
+			return nil, err
+		}
+		releaseMethod := release
+		release = func() {
+			releaseAPI()
+			releaseMethod()
+		}
+	}
+	return
+}
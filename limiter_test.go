@@ -0,0 +1,120 @@
+package zabbix
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLimiterRate(t *testing.T) {
+	l := NewLimiter(100, 2, 0)
+	start := time.Now()
+	for i := 0; i < 6; i++ {
+		release, err := l.Acquire(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		release()
+	}
+	// 2 calls from burst, 4 more at 100 per second
+	if d := time.Since(start); d < 35*time.Millisecond {
+		t.Errorf("Calls were not limited: %s", d)
+	}
+
+	l = NewLimiter(0.001, 1, 0)
+	l.Acquire(context.Background())
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := l.Acquire(ctx); err != context.DeadlineExceeded {
+		t.Errorf("Expected DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestLimiterInFlight(t *testing.T) {
+	var inFlight, peak int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			m := atomic.LoadInt32(&peak)
+			if n <= m || atomic.CompareAndSwapInt32(&peak, m, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		fmt.Fprint(w, `{"jsonrpc":"2.0","result":[],"id":1}`)
+	}))
+	defer srv.Close()
+
+	api := NewAPI(srv.URL)
+	api.Limiter = NewLimiter(0, 0, 4)
+	api.MethodLimiters = map[string]*Limiter{"history.get": NewLimiter(0, 0, 1)}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			api.Call("history.get", Params{})
+		}()
+	}
+	wg.Wait()
+	if peak != 1 {
+		t.Errorf("Expected at most 1 concurrent history.get, got %d", peak)
+	}
+
+	peak = 0
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			api.Call("host.get", Params{})
+		}()
+	}
+	wg.Wait()
+	if peak > 4 {
+		t.Errorf("Expected at most 4 concurrent calls, got %d", peak)
+	}
+}
+
+func TestLimiterMethodFirst(t *testing.T) {
+	unblock := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req request
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.Method == "history.get" {
+			<-unblock
+		}
+		fmt.Fprintf(w, `{"jsonrpc":"2.0","result":[],"id":%d}`, req.ID)
+	}))
+	defer srv.Close()
+
+	api := NewAPI(srv.URL)
+	api.Limiter = NewLimiter(0, 0, 2)
+	api.MethodLimiters = map[string]*Limiter{"history.get": NewLimiter(0, 0, 1)}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			api.Call("history.get", Params{})
+		}()
+	}
+	defer wg.Wait()
+	defer close(unblock)
+
+	// history.get calls waiting for method limiter must not hold global slots
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	time.Sleep(10 * time.Millisecond)
+	if _, err := api.CallWithErrorContext(ctx, "host.get", Params{}); err != nil {
+		t.Errorf("host.get was blocked by history.get: %v", err)
+	}
+}
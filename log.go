@@ -0,0 +1,69 @@
+package zabbix
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// logRequest - Logs request body to api.Logger and, at Debug level, to api.Slog.
+func (api *API) logRequest(ctx context.Context, method string, id int32, body []byte) {
+	if api.Logger != nil {
+		api.printf("Request (POST): %s", api.redact(ctx, method, body, false))
+	}
+	if api.Slog != nil && api.Slog.Enabled(ctx, slog.LevelDebug) {
+		api.Slog.DebugContext(ctx, "zabbix request",
+			slog.String("method", method),
+			slog.Int("id", int(id)),
+			slog.String("body", string(api.redact(ctx, method, body, false))),
+		)
+	}
+}
+
+// logResponse - Logs outcome of single attempt to api.Logger and api.Slog.
+// Summary is logged at Info level (Warn for failures), response body at Debug level.
+func (api *API) logResponse(ctx context.Context, a *Attempt, id int32, latency time.Duration, reqSize int, b []byte) {
+	if api.Logger != nil {
+		if a.Err != nil {
+			api.printf("Error   : %s", api.redactError(ctx, a.Method, a.Err))
+		} else if b == nil {
+			api.printf("Response (%d): (streamed)", a.StatusCode)
+		} else {
+			api.printf("Response (%d): %s", a.StatusCode, api.redact(ctx, a.Method, b, true))
+		}
+	}
+	if api.Slog == nil {
+		return
+	}
+
+	level := slog.LevelInfo
+	attrs := []slog.Attr{
+		slog.String("method", a.Method),
+		slog.Int("id", int(id)),
+		slog.Int("attempt", a.Number),
+		slog.Int("status", a.StatusCode),
+		slog.Duration("latency", latency),
+		slog.Int("request_size", reqSize),
+		slog.Int("response_size", len(b)),
+	}
+	if a.Err != nil {
+		level = slog.LevelWarn
+		attrs = append(attrs, slog.String("error", api.redactError(ctx, a.Method, a.Err)))
+	}
+	if a.APIError != nil {
+		level = slog.LevelWarn
+		attrs = append(attrs, slog.Int("error_code", a.APIError.Code), slog.String("error", a.APIError.Data))
+	}
+	if a.StatusCode >= 400 {
+		level = slog.LevelWarn
+	}
+	api.Slog.LogAttrs(ctx, level, "zabbix call", attrs...)
+
+	if a.Err == nil && api.Slog.Enabled(ctx, slog.LevelDebug) {
+		api.Slog.DebugContext(ctx, "zabbix response",
+			slog.String("method", a.Method),
+			slog.Int("id", int(id)),
+			slog.String("body", string(api.redact(ctx, a.Method, b, true))),
+		)
+	}
+}
@@ -0,0 +1,40 @@
+package zabbix
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSlog(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"jsonrpc":"2.0","error":{"code":-32602,"message":"Invalid params.","data":"No permissions."},"id":1}`)
+	}))
+	defer srv.Close()
+
+	var buf bytes.Buffer
+	api := NewAPI(srv.URL)
+	api.Slog = slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	api.Call("user.login", Params{"user": "Admin", "password": "zabbix"})
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("Expected request, summary and response records, got:\n%s", buf.String())
+	}
+	if strings.Contains(buf.String(), "zabbix\\\"") {
+		t.Errorf("Password leaked:\n%s", buf.String())
+	}
+
+	var summary map[string]interface{}
+	if err := json.Unmarshal([]byte(lines[1]), &summary); err != nil {
+		t.Fatal(err)
+	}
+	if summary["level"] != "WARN" || summary["method"] != "user.login" || summary["status"] != 200.0 || summary["error_code"] != -32602.0 {
+		t.Errorf("Bad summary: %v", summary)
+	}
+}
@@ -0,0 +1,229 @@
+package zabbix
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+type (
+	// MacroType - user macro type
+	MacroType int
+)
+
+const (
+	// MacroText - (default) text value
+	MacroText MacroType = 0
+	// MacroSecret - secret text value, not returned by server, 5.0+
+	MacroSecret MacroType = 1
+	// MacroVault - secret stored in vault, value is "path:key", 5.2+
+	MacroVault MacroType = 2
+)
+
+// UnmarshalJSON - json.Unmarshaler interface impl, accepts both number and string
+func (t *MacroType) UnmarshalJSON(b []byte) error {
+	return unmarshalInt(b, (*int)(t))
+}
+
+// UserMacro - host or template macro: https://www.zabbix.com/documentation/5.0/manual/api/reference/usermacro/object
+type UserMacro struct {
+	ID          string    `json:"hostmacroid,omitempty"`
+	HostID      string    `json:"hostid,omitempty"` // host or template Id, not used when creating hosts
+	Macro       string    `json:"macro"`            // like {$MACRO}
+	Value       string    `json:"value"`
+	Type        MacroType `json:"type,omitempty"`        // 5.0+
+	Description string    `json:"description,omitempty"` // 4.4+
+}
+
+// UserMacros - the array of UserMacro
+type UserMacros []UserMacro
+
+// GlobalMacro - global macro: https://www.zabbix.com/documentation/5.0/manual/api/reference/usermacro/object
+type GlobalMacro struct {
+	ID          string    `json:"globalmacroid,omitempty"`
+	Macro       string    `json:"macro"` // like {$MACRO}
+	Value       string    `json:"value"`
+	Type        MacroType `json:"type,omitempty"`        // 5.0+
+	Description string    `json:"description,omitempty"` // 4.4+
+}
+
+// GlobalMacros - the array of GlobalMacro
+type GlobalMacros []GlobalMacro
+
+// macroParams - Returns macros as create/update params for server with capabilities c: type is omitted if not supported,
+// empty value of secret macros is omitted, so updating other fields does not clear secret which is not returned by server.
+// Error is returned for vault macros if server does not support them.
+func macroParams(macros interface{}, c Capabilities) (res []map[string]interface{}, err error) {
+	if res, err = withoutFields(macros); err != nil {
+		return
+	}
+
+	for _, m := range res {
+		num, _ := m["type"].(json.Number)
+		n, _ := num.Int64()
+		t := MacroType(n)
+		if t == MacroVault && !c.VaultMacros {
+			err = fmt.Errorf("Vault macro %s requires Zabbix 5.2 or later.", m["macro"])
+			return nil, err
+		}
+		if t != MacroText && m["value"] == "" {
+			delete(m, "value")
+		}
+		if !c.SecretMacros {
+			delete(m, "type")
+		}
+	}
+	return
+}
+
+// userMacros - Returns Resource for usermacro methods.
+func (api *API) userMacros() *Resource[UserMacro] {
+	r := NewResource(api, "usermacro", "hostmacroid", func(m *UserMacro) *string { return &m.ID })
+	r.Params = func(macros []UserMacro, c Capabilities) ([]map[string]interface{}, error) {
+		return macroParams(macros, c)
+	}
+	return r
+}
+
+// globalMacros - Returns Resource for usermacro global methods. Only Get works as is,
+// other methods are called with "global" suffix.
+func (api *API) globalMacros() *Resource[GlobalMacro] {
+	r := NewResource(api, "usermacro", "globalmacroid", func(m *GlobalMacro) *string { return &m.ID })
+	r.Params = func(macros []GlobalMacro, c Capabilities) ([]map[string]interface{}, error) {
+		return macroParams(macros, c)
+	}
+	return r
+}
+
+// UserMacrosGet - Wrapper for usermacro.get: https://www.zabbix.com/documentation/5.0/manual/api/reference/usermacro/get
+// Returns host and template macros.
+func (api *API) UserMacrosGet(params Params) (res UserMacros, err error) {
+	return api.UserMacrosGetContext(context.Background(), params)
+}
+
+// UserMacrosGetContext - Same as UserMacrosGet, but bound to ctx.
+func (api *API) UserMacrosGetContext(ctx context.Context, params Params) (res UserMacros, err error) {
+	res, err = api.userMacros().GetContext(ctx, params)
+	return
+}
+
+// UserMacrosGetByHostIds - Gets macros of hosts or templates.
+func (api *API) UserMacrosGetByHostIds(ids []string) (res UserMacros, err error) {
+	return api.UserMacrosGetByHostIdsContext(context.Background(), ids)
+}
+
+// UserMacrosGetByHostIdsContext - Same as UserMacrosGetByHostIds, but bound to ctx.
+func (api *API) UserMacrosGetByHostIdsContext(ctx context.Context, ids []string) (res UserMacros, err error) {
+	return api.UserMacrosGetContext(ctx, Params{"hostids": ids})
+}
+
+// UserMacrosCreate - Wrapper for usermacro.create: https://www.zabbix.com/documentation/5.0/manual/api/reference/usermacro/create
+// Fields not supported by server version are omitted; if version is unknown, macros are sent as is.
+// Vault macros are not supported before 5.2, error is returned for them.
+func (api *API) UserMacrosCreate(macros UserMacros) (err error) {
+	return api.UserMacrosCreateContext(context.Background(), macros)
+}
+
+// UserMacrosCreateContext - Same as UserMacrosCreate, but bound to ctx.
+func (api *API) UserMacrosCreateContext(ctx context.Context, macros UserMacros) (err error) {
+	return api.userMacros().CreateContext(ctx, macros)
+}
+
+// UserMacrosUpdate - Wrapper for usermacro.update: https://www.zabbix.com/documentation/5.0/manual/api/reference/usermacro/update
+// Empty value of secret macro is not sent, so secret is kept.
+func (api *API) UserMacrosUpdate(macros UserMacros) (err error) {
+	return api.UserMacrosUpdateContext(context.Background(), macros)
+}
+
+// UserMacrosUpdateContext - Same as UserMacrosUpdate, but bound to ctx.
+func (api *API) UserMacrosUpdateContext(ctx context.Context, macros UserMacros) (err error) {
+	return api.userMacros().UpdateContext(ctx, macros)
+}
+
+// UserMacrosDelete - Wrapper for usermacro.delete: https://www.zabbix.com/documentation/5.0/manual/api/reference/usermacro/delete
+// Cleans ID in all macros elements if call succeed.
+func (api *API) UserMacrosDelete(macros UserMacros) (err error) {
+	return api.UserMacrosDeleteContext(context.Background(), macros)
+}
+
+// UserMacrosDeleteContext - Same as UserMacrosDelete, but bound to ctx.
+func (api *API) UserMacrosDeleteContext(ctx context.Context, macros UserMacros) (err error) {
+	return api.userMacros().DeleteContext(ctx, macros)
+}
+
+// UserMacrosDeleteByIds - Wrapper for usermacro.delete: https://www.zabbix.com/documentation/5.0/manual/api/reference/usermacro/delete
+func (api *API) UserMacrosDeleteByIds(ids []string) (err error) {
+	return api.UserMacrosDeleteByIdsContext(context.Background(), ids)
+}
+
+// UserMacrosDeleteByIdsContext - Same as UserMacrosDeleteByIds, but bound to ctx.
+func (api *API) UserMacrosDeleteByIdsContext(ctx context.Context, ids []string) (err error) {
+	return api.userMacros().DeleteByIdsContext(ctx, ids)
+}
+
+// GlobalMacrosGet - Wrapper for usermacro.get with globalmacro param: https://www.zabbix.com/documentation/5.0/manual/api/reference/usermacro/get
+func (api *API) GlobalMacrosGet(params Params) (res GlobalMacros, err error) {
+	return api.GlobalMacrosGetContext(context.Background(), params)
+}
+
+// GlobalMacrosGetContext - Same as GlobalMacrosGet, but bound to ctx.
+func (api *API) GlobalMacrosGetContext(ctx context.Context, params Params) (res GlobalMacros, err error) {
+	params["globalmacro"] = true
+	res, err = api.globalMacros().GetContext(ctx, params)
+	return
+}
+
+// GlobalMacrosCreate - Wrapper for usermacro.createglobal: https://www.zabbix.com/documentation/5.0/manual/api/reference/usermacro/createglobal
+// Fields not supported by server version are omitted; if version is unknown, macros are sent as is.
+// Vault macros are not supported before 5.2, error is returned for them.
+func (api *API) GlobalMacrosCreate(macros GlobalMacros) (err error) {
+	return api.GlobalMacrosCreateContext(context.Background(), macros)
+}
+
+// GlobalMacrosCreateContext - Same as GlobalMacrosCreate, but bound to ctx.
+func (api *API) GlobalMacrosCreateContext(ctx context.Context, macros GlobalMacros) (err error) {
+	return api.globalMacros().save(ctx, "usermacro.createglobal", macros)
+}
+
+// GlobalMacrosUpdate - Wrapper for usermacro.updateglobal: https://www.zabbix.com/documentation/5.0/manual/api/reference/usermacro/updateglobal
+// Empty value of secret macro is not sent, so secret is kept.
+func (api *API) GlobalMacrosUpdate(macros GlobalMacros) (err error) {
+	return api.GlobalMacrosUpdateContext(context.Background(), macros)
+}
+
+// GlobalMacrosUpdateContext - Same as GlobalMacrosUpdate, but bound to ctx.
+func (api *API) GlobalMacrosUpdateContext(ctx context.Context, macros GlobalMacros) (err error) {
+	return api.globalMacros().save(ctx, "usermacro.updateglobal", macros)
+}
+
+// GlobalMacrosDelete - Wrapper for usermacro.deleteglobal: https://www.zabbix.com/documentation/5.0/manual/api/reference/usermacro/deleteglobal
+// Cleans ID in all macros elements if call succeed.
+func (api *API) GlobalMacrosDelete(macros GlobalMacros) (err error) {
+	return api.GlobalMacrosDeleteContext(context.Background(), macros)
+}
+
+// GlobalMacrosDeleteContext - Same as GlobalMacrosDelete, but bound to ctx.
+func (api *API) GlobalMacrosDeleteContext(ctx context.Context, macros GlobalMacros) (err error) {
+	ids := make([]string, len(macros))
+	for i, macro := range macros {
+		ids[i] = macro.ID
+	}
+
+	err = api.GlobalMacrosDeleteByIdsContext(ctx, ids)
+	if err == nil {
+		for i := range macros {
+			macros[i].ID = ""
+		}
+	}
+	return
+}
+
+// GlobalMacrosDeleteByIds - Wrapper for usermacro.deleteglobal: https://www.zabbix.com/documentation/5.0/manual/api/reference/usermacro/deleteglobal
+func (api *API) GlobalMacrosDeleteByIds(ids []string) (err error) {
+	return api.GlobalMacrosDeleteByIdsContext(context.Background(), ids)
+}
+
+// GlobalMacrosDeleteByIdsContext - Same as GlobalMacrosDeleteByIds, but bound to ctx.
+func (api *API) GlobalMacrosDeleteByIdsContext(ctx context.Context, ids []string) (err error) {
+	return api.globalMacros().deleteByIds(ctx, "usermacro.deleteglobal", ids)
+}
@@ -0,0 +1,135 @@
+package zabbix
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestUserMacros(t *testing.T) {
+	api := getAPI(t)
+
+	group := CreateHostGroup(t)
+	defer DeleteHostGroup(group, t)
+
+	host := CreateHost(group, t)
+	defer DeleteHost(host, t)
+
+	macros := UserMacros{{HostID: host.ID, Macro: "{$TEST_PORT}", Value: "8080"}}
+	if err := api.UserMacrosCreate(macros); err != nil {
+		t.Fatal(err)
+	}
+	macros[0].Value = "8081"
+	if err := api.UserMacrosUpdate(macros); err != nil {
+		t.Fatal(err)
+	}
+	got, err := api.UserMacrosGetByHostIds([]string{host.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].Value != "8081" {
+		t.Errorf("Bad macros: %#v", got)
+	}
+	if err = api.UserMacrosDelete(macros); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMacroParams(t *testing.T) {
+	macros := UserMacros{
+		{HostID: "10", Macro: "{$PORT}", Value: "8080"},
+		{ID: "2", Macro: "{$PASSWORD}", Type: MacroSecret, Description: "kept secret"},
+		{ID: "3", Macro: "{$TOKEN}", Value: "secret/zabbix:token", Type: MacroVault},
+	}
+	for version, expected := range map[string]string{
+		"4.4.0": `[{"hostid":"10","macro":"{$PORT}","value":"8080"},{"description":"kept secret","hostmacroid":"2","macro":"{$PASSWORD}"}]`,
+		"5.0.0": `[{"hostid":"10","macro":"{$PORT}","value":"8080"},{"description":"kept secret","hostmacroid":"2","macro":"{$PASSWORD}","type":1}]`,
+		"5.2.0": `[{"hostid":"10","macro":"{$PORT}","value":"8080"},{"description":"kept secret","hostmacroid":"2","macro":"{$PASSWORD}","type":1},` +
+			`{"hostmacroid":"3","macro":"{$TOKEN}","type":2,"value":"secret/zabbix:token"}]`,
+	} {
+		v, _ := ParseVersion(version)
+		macros := macros
+		if !v.Capabilities().VaultMacros {
+			macros = macros[:2]
+		}
+		params, err := macroParams(macros, v.Capabilities())
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, _ := json.Marshal(params)
+		if string(b) != expected {
+			t.Errorf("%s:\nexpected %s\ngot      %s", version, expected, b)
+		}
+
+		// macros nested in hosts and templates are shaped the same way
+		hosts, err := Hosts{{Host: "h", Macros: macros}}.params(v.Capabilities())
+		if err != nil {
+			t.Fatal(err)
+		}
+		templates, err := Templates{{Host: "t", Macros: macros}}.params(v.Capabilities())
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, nested := range []interface{}{hosts[0]["macros"], templates[0]["macros"]} {
+			if b, _ = json.Marshal(nested); string(b) != expected {
+				t.Errorf("%s nested:\nexpected %s\ngot      %s", version, expected, b)
+			}
+		}
+	}
+
+	for _, version := range []ServerVersion{{4, 4, 0}, {5, 0, 0}} {
+		if _, err := macroParams(macros, version.Capabilities()); err == nil {
+			t.Errorf("%s: expected error for vault macro", version)
+		}
+		if _, err := (Hosts{{Host: "h", Macros: macros}}).params(version.Capabilities()); err == nil {
+			t.Errorf("%s: expected error for vault macro in host", version)
+		}
+	}
+
+	var m UserMacro
+	if err := json.Unmarshal([]byte(`{"hostmacroid":"2","hostid":"10","macro":"{$PASSWORD}","type":"1"}`), &m); err != nil {
+		t.Fatal(err)
+	}
+	if m.Type != MacroSecret || m.HostID != "10" {
+		t.Errorf("Bad macro: %#v", m)
+	}
+}
+
+func TestGlobalMacros(t *testing.T) {
+	api, calls := newMethodAPI(map[string]string{
+		"usermacro.get":          `[{"globalmacroid":"1","macro":"{$SNMP_COMMUNITY}","value":"public"}]`,
+		"usermacro.createglobal": `{"globalmacroids":["5"]}`,
+		"usermacro.updateglobal": `{"globalmacroids":["5"]}`,
+		"usermacro.deleteglobal": `{"globalmacroids":["5"]}`,
+	})
+
+	got, err := api.GlobalMacrosGet(Params{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].ID != "1" || got[0].Value != "public" {
+		t.Errorf("Bad macros: %#v", got)
+	}
+	if p := string((*calls)[0].Params); p != `{"globalmacro":true,"output":"extend"}` {
+		t.Errorf("unexpected get params %s", p)
+	}
+
+	macros := GlobalMacros{{Macro: "{$TIMEOUT}", Value: "10s"}}
+	if err = api.GlobalMacrosCreate(macros); err != nil {
+		t.Fatal(err)
+	}
+	if macros[0].ID != "5" {
+		t.Errorf("Id is not set: %#v", macros)
+	}
+	if err = api.GlobalMacrosUpdate(macros); err != nil {
+		t.Fatal(err)
+	}
+	if err = api.GlobalMacrosDelete(macros); err != nil {
+		t.Fatal(err)
+	}
+	if macros[0].ID != "" {
+		t.Errorf("Id is not cleaned: %#v", macros)
+	}
+	if p := string((*calls)[3].Params); p != `["5"]` {
+		t.Errorf("unexpected delete params %s", p)
+	}
+}
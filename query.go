@@ -0,0 +1,189 @@
+package zabbix
+
+import (
+	"context"
+	"strings"
+)
+
+// Query - Builder for get methods params. Returned Params can be used with any get wrapper:
+//
+//	hosts, err := api.HostsGet(zabbix.NewQuery().
+//		Output("host", "name").
+//		Filter("status", zabbix.Monitored).
+//		Search("name", "web*").SearchWildcards().
+//		Select("interfaces", "ip").
+//		Sort("name").Limit(10).
+//		Params())
+//
+// Zero value is not usable, use NewQuery.
+type Query struct {
+	params    Params
+	filter    map[string]interface{}
+	search    map[string]string
+	sortField []string
+	sortOrder []string
+}
+
+// NewQuery - Returns empty query.
+func NewQuery() *Query {
+	return &Query{params: Params{}}
+}
+
+// Set - Sets param key to value as is, for params not covered by other methods.
+func (q *Query) Set(key string, value interface{}) *Query {
+	q.params[key] = value
+	return q
+}
+
+// IDs - Sets Ids filter like "hostids" or "groupids".
+func (q *Query) IDs(key string, ids ...string) *Query {
+	return q.Set(key, ids)
+}
+
+// Output - Sets returned object fields. Without fields all of them are returned ("extend").
+func (q *Query) Output(fields ...string) *Query {
+	if len(fields) == 0 {
+		return q.Set("output", "extend")
+	}
+	return q.Set("output", fields)
+}
+
+// Filter - Returns only objects with field exactly matching one of values.
+func (q *Query) Filter(field string, values ...interface{}) *Query {
+	if q.filter == nil {
+		q.filter = make(map[string]interface{})
+		q.params["filter"] = q.filter
+	}
+	if len(values) == 1 {
+		q.filter[field] = values[0]
+	} else {
+		q.filter[field] = values
+	}
+	return q
+}
+
+// Search - Returns only objects with field containing value. See also SearchWildcards, SearchByAny and StartSearch.
+func (q *Query) Search(field, value string) *Query {
+	if q.search == nil {
+		q.search = make(map[string]string)
+		q.params["search"] = q.search
+	}
+	q.search[field] = value
+	return q
+}
+
+// SearchWildcards - Enables "*" wildcard in Search values.
+func (q *Query) SearchWildcards() *Query {
+	return q.Set("searchWildcardsEnabled", true)
+}
+
+// SearchByAny - Returns objects matching any of Filter or Search conditions instead of all of them.
+func (q *Query) SearchByAny() *Query {
+	return q.Set("searchByAny", true)
+}
+
+// StartSearch - Makes Search match beginning of field instead of any part.
+func (q *Query) StartSearch() *Query {
+	return q.Set("startSearch", true)
+}
+
+// Select - Returns related objects in object property, e.g. Select("interfaces", "ip") sets "selectInterfaces".
+// Without fields all of them are returned ("extend"). Empty object is ignored.
+func (q *Query) Select(object string, fields ...string) *Query {
+	if object == "" {
+		return q
+	}
+	key := "select" + strings.ToUpper(object[:1]) + object[1:]
+	if len(fields) == 0 {
+		return q.Set(key, "extend")
+	}
+	return q.Set(key, fields)
+}
+
+// SelectCount - Returns number of related objects instead of objects themselves in object property.
+// Empty object is ignored.
+func (q *Query) SelectCount(object string) *Query {
+	if object == "" {
+		return q
+	}
+	key := "select" + strings.ToUpper(object[:1]) + object[1:]
+	return q.Set(key, "count")
+}
+
+// Sort - Sorts result by fields in ascending order. May be combined with SortDesc.
+func (q *Query) Sort(fields ...string) *Query {
+	return q.sort("ASC", fields)
+}
+
+// SortDesc - Sorts result by fields in descending order. May be combined with Sort.
+func (q *Query) SortDesc(fields ...string) *Query {
+	return q.sort("DESC", fields)
+}
+
+func (q *Query) sort(order string, fields []string) *Query {
+	for _, f := range fields {
+		q.sortField = append(q.sortField, f)
+		q.sortOrder = append(q.sortOrder, order)
+	}
+	q.params["sortfield"] = q.sortField
+	q.params["sortorder"] = q.sortOrder
+	return q
+}
+
+// Limit - Limits number of returned objects.
+func (q *Query) Limit(n int) *Query {
+	return q.Set("limit", n)
+}
+
+// CountOutput - Returns number of objects instead of objects themselves. Use with API.Count.
+func (q *Query) CountOutput() *Query {
+	return q.Set("countOutput", true)
+}
+
+// Params - Returns query params. Query may be modified and reused later without changing returned Params.
+func (q *Query) Params() (res Params) {
+	res = make(Params, len(q.params))
+	for k, v := range q.params {
+		res[k] = v
+	}
+	if q.filter != nil {
+		filter := make(map[string]interface{}, len(q.filter))
+		for k, v := range q.filter {
+			filter[k] = v
+		}
+		res["filter"] = filter
+	}
+	if q.search != nil {
+		search := make(map[string]string, len(q.search))
+		for k, v := range q.search {
+			search[k] = v
+		}
+		res["search"] = search
+	}
+	if q.sortField != nil {
+		res["sortfield"] = append([]string(nil), q.sortField...)
+		res["sortorder"] = append([]string(nil), q.sortOrder...)
+	}
+	return
+}
+
+// Count - Calls get method with "countOutput" and returns number of matching objects, e.g.
+// api.Count("host.get", zabbix.NewQuery().Filter("status", zabbix.Monitored).Params()).
+func (api *API) Count(method string, params Params) (res int, err error) {
+	return api.CountContext(context.Background(), method, params)
+}
+
+// CountContext - Same as Count, but bound to ctx.
+func (api *API) CountContext(ctx context.Context, method string, params Params) (res int, err error) {
+	params["countOutput"] = true
+	delete(params, "output")
+	response, err := api.callRaw(ctx, method, params)
+	if err != nil {
+		return
+	}
+
+	var n Int
+	err = decodeResult(method, response.Result, &n)
+	res = int(n)
+	return
+}
@@ -0,0 +1,64 @@
+package zabbix
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestQuery(t *testing.T) {
+	q := NewQuery().
+		Output("host", "name").
+		IDs("groupids", "1", "2").
+		Filter("status", Monitored).
+		Filter("host", "a", "b").
+		Search("name", "web*").SearchWildcards().
+		Select("interfaces", "ip").
+		SelectCount("items").
+		Select("parentTemplates").
+		Sort("name").SortDesc("hostid").
+		Limit(10)
+	p := q.Params()
+	b, err := json.Marshal(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"filter":{"host":["a","b"],"status":0},"groupids":["1","2"],"limit":10,"output":["host","name"],` +
+		`"search":{"name":"web*"},"searchWildcardsEnabled":true,"selectInterfaces":["ip"],"selectItems":"count",` +
+		`"selectParentTemplates":"extend","sortfield":["name","hostid"],"sortorder":["ASC","DESC"]}`
+	if string(b) != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, b)
+	}
+
+	// returned params are not changed by further building
+	q.Filter("status", Unmonitored).Sort("host").Limit(1)
+	if b2, _ := json.Marshal(p); string(b2) != expected {
+		t.Errorf("params changed: %s", b2)
+	}
+
+	if b, _ = json.Marshal(NewQuery().Output().Params()); string(b) != `{"output":"extend"}` {
+		t.Errorf("unexpected params %s", b)
+	}
+
+	// empty object is ignored
+	if b, _ = json.Marshal(NewQuery().Select("").SelectCount("").Params()); string(b) != `{}` {
+		t.Errorf("unexpected params %s", b)
+	}
+}
+
+func TestCount(t *testing.T) {
+	for result, expected := range map[string]int{`"42"`: 42, `42`: 42} {
+		api := newResultAPI(result)
+		n, err := api.Count("host.get", NewQuery().Output().CountOutput().Params())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n != expected {
+			t.Errorf("%s: expected %d, got %d", result, expected, n)
+		}
+	}
+
+	api := newResultAPI(`[{"hostid":"1"}]`)
+	if _, err := api.Count("host.get", Params{}); err == nil {
+		t.Error("expected error for array result")
+	}
+}
@@ -0,0 +1,176 @@
+package zabbix
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+// Redacted - replacement of sensitive values in logged requests and responses
+const Redacted = "[REDACTED]"
+
+// sensitiveFields - field names which are always redacted in logs (lower case)
+var sensitiveFields = map[string]bool{
+	"auth":           true,
+	"password":       true,
+	"passwd":         true,
+	"passwd_current": true,
+	"current_passwd": true,
+	"token":          true,
+	"sessionid":      true,
+	"secret":         true,
+	"privatekey":     true,
+	"authpassphrase": true,
+	"privpassphrase": true,
+	"community":      true,
+}
+
+// sensitiveResults - methods which return secret as whole result
+var sensitiveResults = map[string]bool{
+	"user.login": true,
+}
+
+type batchMethodsKey struct{}
+
+// withBatchMethods - Returns ctx for batch call, so responses in it are redacted according to methods by request Ids.
+func withBatchMethods(ctx context.Context, methods map[int32]string) context.Context {
+	return context.WithValue(ctx, batchMethodsKey{}, methods)
+}
+
+// redact - Returns copy of JSON body b with sensitive values replaced by Redacted.
+// Fields from api.RedactFields are redacted too. Order of fields and numbers are kept as is.
+// Batch responses are redacted per element, using methods from ctx. Non-JSON bodies are returned with current auth token replaced.
+func (api *API) redact(ctx context.Context, method string, b []byte, response bool) []byte {
+	if !json.Valid(b) {
+		if auth := api.Auth(); auth != "" {
+			return bytes.ReplaceAll(b, []byte(auth), []byte(Redacted))
+		}
+		return b
+	}
+
+	b = bytes.TrimSpace(b)
+	methods, _ := ctx.Value(batchMethodsKey{}).(map[int32]string)
+	var res []byte
+	var err error
+	if response && methods != nil && b[0] == '[' {
+		res, err = api.redactBatch(b, methods)
+	} else {
+		res, err = api.redactValue(b, api.resultSensitive(method, response))
+	}
+	if err != nil {
+		return b
+	}
+	return res
+}
+
+// resultSensitive - Returns function which checks top level fields of request or response of method.
+func (api *API) resultSensitive(method string, response bool) func(field string) bool {
+	if response && sensitiveResults[strings.ToLower(method)] {
+		return func(field string) bool { return field == "result" || api.isSensitive(field) }
+	}
+	return api.isSensitive
+}
+
+// redactBatch - Returns batch response b with every element redacted according to method of its Id.
+func (api *API) redactBatch(b []byte, methods map[int32]string) (res []byte, err error) {
+	var elements []json.RawMessage
+	if err = json.Unmarshal(b, &elements); err != nil {
+		return
+	}
+
+	var buf bytes.Buffer
+	buf.WriteByte('[')
+	for i, e := range elements {
+		var head struct {
+			ID int32 `json:"id"`
+		}
+		json.Unmarshal(e, &head)
+		if e, err = api.redactValue(e, api.resultSensitive(methods[head.ID], true)); err != nil {
+			return
+		}
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.Write(e)
+	}
+	buf.WriteByte(']')
+	res = buf.Bytes()
+	return
+}
+
+// redactValue - Returns JSON value b with sensitive fields of objects replaced, recursively.
+// Fields of top level object are checked with sensitive, nested ones with api.isSensitive.
+func (api *API) redactValue(b json.RawMessage, sensitive func(field string) bool) (res []byte, err error) {
+	if len(b) == 0 || (b[0] != '{' && b[0] != '[') {
+		return b, nil
+	}
+
+	d := json.NewDecoder(bytes.NewReader(b))
+	if _, err = d.Token(); err != nil {
+		return
+	}
+	var buf bytes.Buffer
+	buf.WriteByte(b[0])
+	for d.More() {
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
+		}
+
+		key := ""
+		if b[0] == '{' {
+			var t json.Token
+			if t, err = d.Token(); err != nil {
+				return
+			}
+			key, _ = t.(string)
+			k, _ := json.Marshal(key)
+			buf.Write(k)
+			buf.WriteByte(':')
+		}
+
+		var v json.RawMessage
+		if err = d.Decode(&v); err != nil {
+			return
+		}
+		if b[0] == '{' && sensitive(key) {
+			if s := string(v); s != "null" && s != `""` {
+				v = json.RawMessage(`"` + Redacted + `"`)
+			}
+		} else if v, err = api.redactValue(v, api.isSensitive); err != nil {
+			return
+		}
+		buf.Write(v)
+	}
+	if b[0] == '{' {
+		buf.WriteByte('}')
+	} else {
+		buf.WriteByte(']')
+	}
+	res = buf.Bytes()
+	return
+}
+
+// redactError - Returns error message with body of HTTPError redacted.
+func (api *API) redactError(ctx context.Context, method string, err error) string {
+	msg := err.Error()
+	var e *HTTPError
+	if errors.As(err, &e) && e.Body != "" {
+		msg = strings.Replace(msg, e.Body, string(api.redact(ctx, method, []byte(e.Body), true)), 1)
+	}
+	return msg
+}
+
+func (api *API) isSensitive(field string) bool {
+	field = strings.ToLower(field)
+	if sensitiveFields[field] {
+		return true
+	}
+	for _, f := range api.RedactFields {
+		if strings.ToLower(f) == field {
+			return true
+		}
+	}
+	return false
+}
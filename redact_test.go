@@ -0,0 +1,86 @@
+package zabbix
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestRedact(t *testing.T) {
+	api := NewAPI("http://host/api_jsonrpc.php")
+	api.RedactFields = []string{"Value"}
+	api.SetAuth("0424bd59")
+
+	for _, c := range []struct {
+		method   string
+		body     string
+		response bool
+		expected string
+	}{
+		{"user.login", `{"params":{"user":"Admin","password":"zabbix"}}`, false, `{"params":{"user":"Admin","password":"[REDACTED]"}}`},
+		{"user.login", `{"result":"0424bd59b807674191e7d77572075f33"}`, true, `{"result":"[REDACTED]"}`},
+		{"host.get", `{"auth":"0424bd59","params":{}}`, false, `{"auth":"[REDACTED]","params":{}}`},
+		{"usermacro.create", `[{"params":[{"macro":"{$A}","value":"b"}]}]`, false, `[{"params":[{"macro":"{$A}","value":"[REDACTED]"}]}]`},
+		{"host.get", `<html>502 Bad Gateway</html>`, true, `<html>502 Bad Gateway</html>`},
+		{"history.get", `{"result":[{"clock":"1","value":12345678901234567890,"ns":1.5e3}],"id":1}`, true,
+			`{"result":[{"clock":"1","value":"[REDACTED]","ns":1.5e3}],"id":1}`},
+		{"item.get", `{"result":[{"itemid":12345678901234567890,"value":""}]}`, true, `{"result":[{"itemid":12345678901234567890,"value":""}]}`},
+		{"host.get", `<html>Session 0424bd59 expired</html>`, true, `<html>Session [REDACTED] expired</html>`},
+	} {
+		actual := string(api.redact(context.Background(), c.method, []byte(c.body), c.response))
+		if actual != c.expected {
+			t.Errorf("%s %s: expected %s, got %s", c.method, c.body, c.expected, actual)
+		}
+		if strings.Contains(actual, "zabbix") || strings.Contains(actual, "0424bd59") {
+			t.Errorf("Secret leaked: %s", actual)
+		}
+	}
+}
+
+func TestRedactHTTPError(t *testing.T) {
+	api := NewAPI("http://host/api_jsonrpc.php")
+	api.SetAuth("0424bd59")
+	err := fmt.Errorf("call failed: %w", &HTTPError{Status: "502 Bad Gateway", Body: `{"auth":"secret"} 0424bd59`})
+	msg := api.redactError(context.Background(), "host.get", err)
+	if strings.Contains(msg, "0424bd59") || !strings.HasPrefix(msg, "call failed: Unexpected HTTP response 502") {
+		t.Errorf("Secret leaked: %s", msg)
+	}
+}
+
+func TestRedactBatch(t *testing.T) {
+	api := NewAPI("http://host/api_jsonrpc.php")
+	ctx := withBatchMethods(context.Background(), map[int32]string{1: "user.login", 2: "APIInfo.version"})
+	body := `[{"jsonrpc":"2.0","result":"SESSIONSECRET","id":1},{"jsonrpc":"2.0","result":"7.0.0","id":2}]`
+	expected := `[{"jsonrpc":"2.0","result":"[REDACTED]","id":1},{"jsonrpc":"2.0","result":"7.0.0","id":2}]`
+	if actual := string(api.redact(ctx, "APIInfo.version", []byte(body), true)); actual != expected {
+		t.Errorf("expected %s\ngot      %s", expected, actual)
+	}
+
+	var logs bytes.Buffer
+	api = newResultAPI(`"x"`)
+	api.Logger = log.New(&logs, "", 0)
+	api.SetClient(&http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		var reqs []request
+		json.NewDecoder(req.Body).Decode(&reqs)
+		body := fmt.Sprintf(`[{"jsonrpc":"2.0","result":"SESSIONSECRET","id":%d}]`, reqs[0].ID)
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": {"application/json"}},
+			Body:       ioutil.NopCloser(strings.NewReader(body)),
+		}, nil
+	})})
+	batch := api.NewBatch()
+	batch.Add("user.login", Params{"username": "Admin", "password": "zabbix"}, nil)
+	if err := batch.Send(); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(logs.String(), Redacted) || strings.Contains(logs.String(), "SESSIONSECRET") || strings.Contains(logs.String(), "zabbix\"") {
+		t.Errorf("Secret leaked: %s", logs.String())
+	}
+}
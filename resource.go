@@ -0,0 +1,212 @@
+package zabbix
+
+import (
+	"context"
+)
+
+// Resource - Generic wrapper for Zabbix API object of type T with methods <Prefix>.get, <Prefix>.create,
+// <Prefix>.update and <Prefix>.delete, like host or item. It makes adding wrappers for new objects trivial:
+//
+//	maintenances := zabbix.NewResource(api, "maintenance", "maintenanceid", func(m *Maintenance) *string { return &m.ID })
+//	res, err := maintenances.Get(zabbix.Params{"groupids": ids})
+type Resource[T any] struct {
+	API *API
+
+	// Prefix - API method prefix, e.g. "host".
+	Prefix string
+
+	// IDKey - name of object Id field, e.g. "hostid". Get filter is IDKey + "s", create, update and delete
+	// results are read from the same key.
+	IDKey string
+
+	// ID - Returns pointer to object Id field.
+	ID func(object *T) *string
+
+	// Params - If not nil, returns create and update params for server with capabilities c,
+	// e.g. with fields unsupported by server omitted. It is not used if server version is unknown.
+	Params func(objects []T, c Capabilities) ([]map[string]interface{}, error)
+}
+
+// NewResource - Returns Resource for objects of type T with given method prefix and Id field.
+func NewResource[T any](api *API, prefix, idKey string, id func(object *T) *string) *Resource[T] {
+	return &Resource[T]{API: api, Prefix: prefix, IDKey: idKey, ID: id}
+}
+
+// Get - Wrapper for <Prefix>.get. Output defaults to "extend".
+func (r *Resource[T]) Get(params Params) (res []T, err error) {
+	return r.GetContext(context.Background(), params)
+}
+
+// GetContext - Same as Get, but bound to ctx.
+func (r *Resource[T]) GetContext(ctx context.Context, params Params) (res []T, err error) {
+	method := r.Prefix + ".get"
+	if _, present := params["output"]; !present {
+		params["output"] = "extend"
+	}
+	response, err := r.API.callRaw(ctx, method, params)
+	if err != nil {
+		return
+	}
+
+	err = decodeResult(method, response.Result, &res)
+	return
+}
+
+// Each - Same as Get, but passes objects to fn one by one as they are read from response, so memory usage
+// does not depend on number of objects. If fn returns error, call is stopped and error is returned.
+// Like CallEach, it does not use interceptors and retries.
+func (r *Resource[T]) Each(params Params, fn func(object *T) error) (err error) {
+	return r.EachContext(context.Background(), params, fn)
+}
+
+// EachContext - Same as Each, but bound to ctx.
+func (r *Resource[T]) EachContext(ctx context.Context, params Params, fn func(object *T) error) (err error) {
+	return streamEach(ctx, r.API, r.Prefix+".get", params, fn)
+}
+
+// GetByID - Gets object by Id only if there is exactly 1 matching object.
+func (r *Resource[T]) GetByID(id string) (res *T, err error) {
+	return r.GetByIDContext(context.Background(), id)
+}
+
+// GetByIDContext - Same as GetByID, but bound to ctx.
+func (r *Resource[T]) GetByIDContext(ctx context.Context, id string) (res *T, err error) {
+	return r.GetOneContext(ctx, Params{r.IDKey + "s": id})
+}
+
+// GetOne - Gets object by params only if there is exactly 1 matching object.
+func (r *Resource[T]) GetOne(params Params) (res *T, err error) {
+	return r.GetOneContext(context.Background(), params)
+}
+
+// GetOneContext - Same as GetOne, but bound to ctx.
+func (r *Resource[T]) GetOneContext(ctx context.Context, params Params) (res *T, err error) {
+	objects, err := r.GetContext(ctx, params)
+	if err != nil {
+		return
+	}
+
+	if len(objects) == 1 {
+		res = &objects[0]
+	} else {
+		e := ExpectedOneResult(len(objects))
+		err = &e
+	}
+	return
+}
+
+// Create - Wrapper for <Prefix>.create. Sets Id in all objects elements if call succeed.
+func (r *Resource[T]) Create(objects []T) (err error) {
+	return r.CreateContext(context.Background(), objects)
+}
+
+// CreateContext - Same as Create, but bound to ctx.
+func (r *Resource[T]) CreateContext(ctx context.Context, objects []T) (err error) {
+	return r.save(ctx, r.Prefix+".create", objects)
+}
+
+// Update - Wrapper for <Prefix>.update.
+func (r *Resource[T]) Update(objects []T) (err error) {
+	return r.UpdateContext(context.Background(), objects)
+}
+
+// UpdateContext - Same as Update, but bound to ctx.
+func (r *Resource[T]) UpdateContext(ctx context.Context, objects []T) (err error) {
+	return r.save(ctx, r.Prefix+".update", objects)
+}
+
+// save - Calls create or update method with objects and sets their Ids from result.
+func (r *Resource[T]) save(ctx context.Context, method string, objects []T) (err error) {
+	var params interface{} = objects
+	if r.Params != nil {
+		if caps, e := r.API.CapabilitiesContext(ctx); e == nil {
+			if params, err = r.Params(objects, caps); err != nil {
+				return
+			}
+		}
+	}
+
+	response, err := r.API.callRaw(ctx, method, params)
+	if err != nil {
+		return
+	}
+
+	ids, err := resultIds(method, response.Result, r.IDKey+"s")
+	if err != nil {
+		return
+	}
+	if len(ids) != len(objects) {
+		err = &ExpectedMore{len(objects), len(ids)}
+		return
+	}
+	for i, id := range ids {
+		*r.ID(&objects[i]) = id
+	}
+	return
+}
+
+// Delete - Wrapper for <Prefix>.delete. Cleans Id in all objects elements if call succeed.
+func (r *Resource[T]) Delete(objects []T) (err error) {
+	return r.DeleteContext(context.Background(), objects)
+}
+
+// DeleteContext - Same as Delete, but bound to ctx.
+func (r *Resource[T]) DeleteContext(ctx context.Context, objects []T) (err error) {
+	ids := make([]string, len(objects))
+	for i := range objects {
+		ids[i] = *r.ID(&objects[i])
+	}
+
+	err = r.DeleteByIdsContext(ctx, ids)
+	if err == nil {
+		for i := range objects {
+			*r.ID(&objects[i]) = ""
+		}
+	}
+	return
+}
+
+// DeleteByIds - Wrapper for <Prefix>.delete.
+func (r *Resource[T]) DeleteByIds(ids []string) (err error) {
+	return r.DeleteByIdsContext(context.Background(), ids)
+}
+
+// DeleteByIdsContext - Same as DeleteByIds, but bound to ctx.
+func (r *Resource[T]) DeleteByIdsContext(ctx context.Context, ids []string) (err error) {
+	return r.deleteByIds(ctx, r.Prefix+".delete", ids)
+}
+
+// deleteByIds - Calls delete method with ids and checks that all of them are deleted.
+func (r *Resource[T]) deleteByIds(ctx context.Context, method string, ids []string) (err error) {
+	response, err := r.API.callRaw(ctx, method, ids)
+	if err != nil {
+		return
+	}
+
+	// some versions actually return map there, resultIds handles it
+	deleted, err := resultIds(method, response.Result, r.IDKey+"s")
+	if err != nil {
+		return
+	}
+	if len(ids) != len(deleted) {
+		err = &ExpectedMore{len(ids), len(deleted)}
+	}
+	return
+}
+
+// massUpdate - Calls mass update method and checks that result contains expected number of Ids under key.
+func (api *API) massUpdate(ctx context.Context, method string, params Params, key string, expected int) (err error) {
+	response, err := api.callRaw(ctx, method, params)
+	if err != nil {
+		return
+	}
+
+	ids, err := resultIds(method, response.Result, key)
+	if err != nil {
+		return
+	}
+	if len(ids) != expected {
+		err = &ExpectedMore{expected, len(ids)}
+	}
+	return
+}
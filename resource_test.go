@@ -0,0 +1,125 @@
+package zabbix
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+type maintenance struct {
+	ID   string `json:"maintenanceid,omitempty"`
+	Name string `json:"name"`
+}
+
+// recordedCall - method and params of call made to API returned by newMethodAPI
+type recordedCall struct {
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+}
+
+// newMethodAPI - Returns API which gets results[method] for every call, and records made calls.
+func newMethodAPI(results map[string]string) (api *API, calls *[]recordedCall) {
+	calls = new([]recordedCall)
+	api = NewAPI("http://zabbix/api_jsonrpc.php")
+	api.SetVersion(ServerVersion{3, 0, 0})
+	api.SetClient(&http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		var c recordedCall
+		b, _ := ioutil.ReadAll(req.Body)
+		json.Unmarshal(b, &c)
+		*calls = append(*calls, c)
+		body := `{"jsonrpc":"2.0","result":` + results[c.Method] + `,"id":1}`
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": {"application/json"}},
+			Body:       ioutil.NopCloser(strings.NewReader(body)),
+		}, nil
+	})})
+	return
+}
+
+func TestResource(t *testing.T) {
+	results := map[string]string{
+		"maintenance.get":    `[{"maintenanceid":"5","name":"m"}]`,
+		"maintenance.create": `{"maintenanceids":["1","2"]}`,
+		"maintenance.update": `{"maintenanceids":["1","2"]}`,
+		"maintenance.delete": `{"maintenanceids":["1","2"]}`,
+	}
+	api, calls := newMethodAPI(results)
+
+	r := NewResource(api, "maintenance", "maintenanceid", func(m *maintenance) *string { return &m.ID })
+	m, err := r.GetByID("5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.ID != "5" || m.Name != "m" {
+		t.Errorf("unexpected object %#v", m)
+	}
+	if p := string((*calls)[0].Params); p != `{"maintenanceids":"5","output":"extend"}` {
+		t.Errorf("unexpected get params %s", p)
+	}
+
+	objects := []maintenance{{Name: "a"}, {Name: "b"}}
+	if err = r.Create(objects); err != nil {
+		t.Fatal(err)
+	}
+	if objects[0].ID != "1" || objects[1].ID != "2" {
+		t.Errorf("Ids are not set: %#v", objects)
+	}
+	if err = r.Update(objects); err != nil {
+		t.Fatal(err)
+	}
+	if err = r.Delete(objects); err != nil {
+		t.Fatal(err)
+	}
+	if objects[0].ID != "" || objects[1].ID != "" {
+		t.Errorf("Ids are not cleaned: %#v", objects)
+	}
+	if p := string((*calls)[3].Params); p != `["1","2"]` {
+		t.Errorf("unexpected delete params %s", p)
+	}
+
+	if err = r.DeleteByIds([]string{"1"}); err == nil {
+		t.Error("expected error for result count mismatch")
+	} else if _, ok := err.(*ExpectedMore); !ok {
+		t.Errorf("unexpected error %T %s", err, err)
+	}
+
+	var methods []string
+	for _, c := range *calls {
+		methods = append(methods, c.Method)
+	}
+	expected := "maintenance.get maintenance.create maintenance.update maintenance.delete maintenance.delete"
+	if actual := strings.Join(methods, " "); actual != expected {
+		t.Errorf("expected %s, got %s", expected, actual)
+	}
+}
+
+func TestResourceEach(t *testing.T) {
+	api, calls := newMethodAPI(map[string]string{"maintenance.get": `[{"maintenanceid":"5","name":"m"},{"maintenanceid":"6","name":"n"}]`})
+	r := NewResource(api, "maintenance", "maintenanceid", func(m *maintenance) *string { return &m.ID })
+
+	var names []string
+	err := r.Each(Params{"maintenanceids": []string{"5", "6"}}, func(m *maintenance) error {
+		names = append(names, m.ID+":"+m.Name)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(names, ",") != "5:m,6:n" {
+		t.Errorf("unexpected objects %v", names)
+	}
+	if p := string((*calls)[0].Params); p != `{"maintenanceids":["5","6"],"output":"extend"}` {
+		t.Errorf("unexpected get params %s", p)
+	}
+
+	api, _ = newMethodAPI(map[string]string{"maintenance.get": `[{"maintenanceid":5}]`})
+	r.API = api
+	var e *UnexpectedResult
+	if err = r.Each(Params{}, func(m *maintenance) error { return nil }); !errors.As(err, &e) {
+		t.Errorf("expected UnexpectedResult, got %v", err)
+	}
+}
@@ -0,0 +1,123 @@
+package zabbix
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// UnexpectedResult - result of API method has unexpected shape, for example because of server version differences
+type UnexpectedResult struct {
+	Method string
+	Result interface{}
+	Err    error // decoding error, may be nil
+}
+
+// Error - error interface impl
+func (e *UnexpectedResult) Error() string {
+	s := snippet(fmt.Sprintf("%v", e.Result))
+	if e.Err != nil {
+		return fmt.Sprintf("Unexpected result of %s: %s (%s).", e.Method, s, e.Err)
+	}
+	return fmt.Sprintf("Unexpected result of %s: %s.", e.Method, s)
+}
+
+// Unwrap - Returns decoding error.
+func (e *UnexpectedResult) Unwrap() error {
+	return e.Err
+}
+
+type rawResultKey struct{}
+
+// withRawResult - Returns ctx for calls which response.Result is left as json.RawMessage,
+// so it is decoded only once by decodeResult.
+func withRawResult(ctx context.Context) context.Context {
+	return context.WithValue(ctx, rawResultKey{}, true)
+}
+
+// callRaw - Same as CallWithErrorContext, but leaves response.Result as json.RawMessage to be decoded by decodeResult.
+func (api *API) callRaw(ctx context.Context, method string, params interface{}) (response Response, err error) {
+	return api.CallWithErrorContext(withRawResult(ctx), method, params)
+}
+
+// decodeResult - Decodes result of method into v, which should be a pointer.
+func decodeResult(method string, result interface{}, v interface{}) (err error) {
+	b, ok := result.(json.RawMessage)
+	if !ok {
+		if b, err = json.Marshal(result); err != nil {
+			return &UnexpectedResult{method, result, err}
+		}
+	}
+	if err = json.Unmarshal(b, v); err != nil {
+		return &UnexpectedResult{method, result, err}
+	}
+	return
+}
+
+// idList - array of object Ids. Some Zabbix versions return object {"0": "1", "1": "2"} instead of array,
+// and numbers instead of strings.
+type idList []string
+
+// UnmarshalJSON - json.Unmarshaler interface impl
+func (l *idList) UnmarshalJSON(b []byte) (err error) {
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+	var v interface{}
+	if err = d.Decode(&v); err != nil {
+		return
+	}
+
+	var values []interface{}
+	switch v := v.(type) {
+	case []interface{}:
+		values = v
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			a, errA := strconv.Atoi(keys[i])
+			b, errB := strconv.Atoi(keys[j])
+			if errA != nil || errB != nil {
+				return keys[i] < keys[j]
+			}
+			return a < b
+		})
+		for _, k := range keys {
+			values = append(values, v[k])
+		}
+	default:
+		return fmt.Errorf("Expected array of Ids, got %s.", b)
+	}
+
+	*l = make(idList, len(values))
+	for i, id := range values {
+		switch id := id.(type) {
+		case string:
+			(*l)[i] = id
+		case json.Number:
+			(*l)[i] = id.String()
+		default:
+			return fmt.Errorf("Expected Id, got %v.", id)
+		}
+	}
+	return
+}
+
+// resultIds - Decodes Ids under key from result of create/update/delete method, like {"hostids": ["1", "2"]}.
+func resultIds(method string, result interface{}, key string) (ids []string, err error) {
+	var m map[string]idList
+	if err = decodeResult(method, result, &m); err != nil {
+		return
+	}
+	l, ok := m[key]
+	if !ok {
+		err = &UnexpectedResult{Method: method, Result: result}
+		return
+	}
+	return l, nil
+}
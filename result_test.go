@@ -0,0 +1,117 @@
+package zabbix
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// newResultAPI - Returns API which gets result for every call.
+func newResultAPI(result string) *API {
+	api := NewAPI("http://zabbix/api_jsonrpc.php")
+	api.SetVersion(ServerVersion{3, 0, 0})
+	api.SetClient(&http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		body := `{"jsonrpc":"2.0","result":` + result + `,"id":1}`
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": {"application/json"}},
+			Body:       ioutil.NopCloser(strings.NewReader(body)),
+		}, nil
+	})})
+	return api
+}
+
+func TestResultIds(t *testing.T) {
+	for result, expected := range map[string]string{
+		`{"itemids":["1","2"]}`:           "1,2",
+		`{"itemids":{"1":"3","0":"2"}}`:   "2,3",
+		`{"itemids":[10,11]}`:             "10,11",
+		`{"itemids":{"10":"5","9":"4"}}`:  "4,5",
+		`{"itemids":[]}`:                  "",
+		`{"hostids":["1"]}`:               "error",
+		`["1"]`:                           "error",
+		`{"itemids":[{"itemid":"1"}]}`:    "error",
+		`"Session terminated, re-login."`: "error",
+	} {
+		var v interface{}
+		json.Unmarshal([]byte(result), &v)
+		ids, err := resultIds("item.delete", v, "itemids")
+		actual := strings.Join(ids, ",")
+		if err != nil {
+			actual = "error"
+			var e *UnexpectedResult
+			if !errors.As(err, &e) {
+				t.Errorf("%s: unexpected error type %T", result, err)
+			}
+		}
+		if actual != expected {
+			t.Errorf("%s: expected %s, got %s", result, expected, actual)
+		}
+	}
+}
+
+func TestUnexpectedResults(t *testing.T) {
+	api := newResultAPI(`{"unexpected":true}`)
+	if err := api.HostsCreate(Hosts{{Host: "test"}}); err == nil {
+		t.Error("Expected error from HostsCreate")
+	}
+	if _, err := api.Login("user", "password"); err == nil {
+		t.Error("Expected error from Login")
+	}
+	if _, err := api.HostsGet(Params{}); err == nil {
+		t.Error("Expected error from HostsGet")
+	}
+
+	api = newResultAPI(`{"hostids":["1","2"]}`)
+	if err := api.HostsCreate(Hosts{{Host: "test"}}); err == nil {
+		t.Error("Expected error for extra Ids")
+	}
+}
+
+func FuzzWrappers(f *testing.F) {
+	for _, seed := range []string{
+		`"3.0.0"`, `null`, `[]`, `{}`, `[1, "2", null]`, `[{"hostid":"1"}]`, `[{"hostid":{}}]`,
+		`{"hostids":["1"]}`, `{"hostids":{"0":"1"}}`, `{"itemids":[[]]}`, `{"tokenids":"1"}`,
+		`[{"tokenid":"1","token":"secret"}]`, `[{"tokenid":1}]`, `{"sessionid":"1","userid":2}`,
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, result string) {
+		if !json.Valid([]byte(result)) {
+			return
+		}
+
+		api := newResultAPI(result)
+		api.Login("user", "password")
+		api.Version()
+		api.CheckAuthentication()
+		api.HostsGet(Params{})
+		api.HostsCreate(Hosts{{Host: "test"}})
+		api.HostsUpdate(Hosts{{ID: "1"}})
+		api.HostsDeleteByIds([]string{"1"})
+		api.HostGroupsGet(Params{})
+		api.HostGroupsCreate(HostGroups{{Name: "test"}})
+		api.HostGroupsDeleteByIds([]string{"1"})
+		api.ItemsGet(Params{})
+		api.ItemsCreate(Items{{Key: "test"}})
+		api.ItemsDeleteByIds([]string{"1"})
+		api.ApplicationsGet(Params{})
+		api.ApplicationsCreate(Applications{{Name: "test"}})
+		api.ApplicationsDeleteByIds([]string{"1"})
+		api.HistorysGet(Params{})
+		api.TokensGet(Params{})
+		api.TokensCreate(Tokens{{Name: "test"}})
+		api.TokensGenerate([]string{"1"})
+		api.TokensDeleteByIds([]string{"1"})
+	})
+}
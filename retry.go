@@ -0,0 +1,114 @@
+package zabbix
+
+import (
+	"context"
+	"encoding/json"
+	"math/rand"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Attempt - outcome of a single HTTP round-trip, passed to RetryPolicy.
+type Attempt struct {
+	Method     string // JSON-RPC method, like "host.get"
+	Number     int    // 1 for the first attempt
+	StatusCode int    // HTTP status code, 0 if no response was received
+	Err        error  // transport error, nil if response was received
+	APIError   *Error // JSON-RPC error from response body, if any
+}
+
+// RetryPolicy - decides whether failed call should be made again.
+type RetryPolicy interface {
+	// Retry returns delay before the next attempt and whether it should be made at all.
+	Retry(a *Attempt) (delay time.Duration, retry bool)
+}
+
+// Backoff - RetryPolicy with exponential backoff and jitter.
+type Backoff struct {
+	MaxAttempts int                   // total number of attempts, including the first one
+	BaseDelay   time.Duration         // delay before the second attempt, doubled for every next one
+	MaxDelay    time.Duration         // upper bound of a single delay, 0 means no bound
+	Jitter      float64               // fraction of delay which is randomized, from 0 to 1
+	Retryable   func(a *Attempt) bool // classifier, DefaultRetryable if nil
+}
+
+// Retry - RetryPolicy interface impl
+func (b *Backoff) Retry(a *Attempt) (delay time.Duration, retry bool) {
+	if a.Number >= b.MaxAttempts {
+		return
+	}
+	retryable := b.Retryable
+	if retryable == nil {
+		retryable = DefaultRetryable
+	}
+	if !retryable(a) {
+		return
+	}
+
+	delay = b.BaseDelay
+	for i := 1; i < a.Number && (b.MaxDelay == 0 || delay < b.MaxDelay); i++ {
+		delay *= 2
+	}
+	if b.MaxDelay > 0 && delay > b.MaxDelay {
+		delay = b.MaxDelay
+	}
+	if b.Jitter > 0 && delay > 0 {
+		j := time.Duration(b.Jitter * float64(delay))
+		if j > delay {
+			j = delay
+		}
+		delay = delay - j + time.Duration(rand.Int63n(int64(j)+1))
+	}
+	return delay, true
+}
+
+// IsReadOnlyMethod - Returns true for methods which do not modify anything on server (*.get and APIInfo.version),
+// so they are safe to replay.
+func IsReadOnlyMethod(method string) bool {
+	method = strings.ToLower(method)
+	return strings.HasSuffix(method, ".get") || method == "apiinfo.version"
+}
+
+// DefaultRetryable - Retries read-only methods after transport errors, 502/503/504 responses and
+// database errors reported by API. Mutating methods are never retried.
+func DefaultRetryable(a *Attempt) bool {
+	if !IsReadOnlyMethod(a.Method) {
+		return false
+	}
+	if a.Err != nil {
+		return true
+	}
+
+	switch a.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+
+	if a.APIError != nil {
+		data := strings.ToLower(a.APIError.Data)
+		return strings.Contains(data, "database") || strings.Contains(data, "deadlock")
+	}
+	return false
+}
+
+// peekError - Extracts JSON-RPC error from response body, if any.
+func peekError(b []byte) *Error {
+	var response struct {
+		Error *Error `json:"error"`
+	}
+	json.Unmarshal(b, &response)
+	return response.Error
+}
+
+// sleepContext - Waits for d or until ctx is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,62 @@
+package zabbix
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newFlakyServer(failures int32) (*httptest.Server, *int32) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) <= failures {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{"jsonrpc":"2.0","result":[],"id":1}`)
+	}))
+	return srv, &calls
+}
+
+func TestRetryReadOnly(t *testing.T) {
+	srv, calls := newFlakyServer(2)
+	defer srv.Close()
+
+	api := NewAPI(srv.URL)
+	api.Retry = &Backoff{MaxAttempts: 3, BaseDelay: time.Millisecond}
+	if _, err := api.CallWithError("host.get", Params{}); err != nil {
+		t.Fatal(err)
+	}
+	if *calls != 3 {
+		t.Errorf("Expected 3 calls, got %d", *calls)
+	}
+}
+
+func TestRetryMutating(t *testing.T) {
+	srv, calls := newFlakyServer(2)
+	defer srv.Close()
+
+	api := NewAPI(srv.URL)
+	api.Retry = &Backoff{MaxAttempts: 3, BaseDelay: time.Millisecond}
+	api.Call("host.create", Hosts{})
+	if *calls != 1 {
+		t.Errorf("Expected 1 call, got %d", *calls)
+	}
+}
+
+func TestBackoffDelay(t *testing.T) {
+	b := &Backoff{MaxAttempts: 10, BaseDelay: time.Second, MaxDelay: 5 * time.Second}
+	expected := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}
+	for i, e := range expected {
+		d, retry := b.Retry(&Attempt{Method: "item.get", Number: i + 1, Err: fmt.Errorf("reset")})
+		if !retry || d != e {
+			t.Errorf("Attempt %d: expected %s, got %s (%v)", i+1, e, d, retry)
+		}
+	}
+	if _, retry := b.Retry(&Attempt{Method: "item.get", Number: 10, Err: fmt.Errorf("reset")}); retry {
+		t.Error("Expected no retry after MaxAttempts")
+	}
+}
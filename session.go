@@ -0,0 +1,182 @@
+package zabbix
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+)
+
+var _ io.Closer = (*API)(nil)
+
+// Session - user session info returned by user.checkAuthentication:
+// https://www.zabbix.com/documentation/3.0/manual/api/reference/user/checkauthentication
+type Session struct {
+	SessionID  string `json:"sessionid"`
+	UserID     string `json:"userid"`
+	Alias      string `json:"alias"`    // before Zabbix 5.4
+	Username   string `json:"username"` // Zabbix 5.4+
+	Name       string `json:"name"`
+	Surname    string `json:"surname"`
+	Type       Int    `json:"type"`
+	Lang       string `json:"lang"`
+	AutoLogout string `json:"autologout"`
+	UserIP     string `json:"userip"`
+}
+
+// CredentialsProvider - source of user name and password for automatic re-login.
+type CredentialsProvider interface {
+	Credentials(ctx context.Context) (user, password string, err error)
+}
+
+// Credentials - static CredentialsProvider
+type Credentials struct {
+	User     string
+	Password string
+}
+
+// Credentials - CredentialsProvider interface impl
+func (c *Credentials) Credentials(ctx context.Context) (user, password string, err error) {
+	return c.User, c.Password, nil
+}
+
+// CredentialsFunc - adapter to use ordinary function as CredentialsProvider
+type CredentialsFunc func(ctx context.Context) (user, password string, err error)
+
+// Credentials - CredentialsProvider interface impl
+func (f CredentialsFunc) Credentials(ctx context.Context) (user, password string, err error) {
+	return f(ctx)
+}
+
+// IsSessionExpired - Returns true if e is returned by server for expired or invalid session.
+func IsSessionExpired(e *Error) bool {
+	if e == nil {
+		return false
+	}
+	data := strings.ToLower(e.Data)
+	return strings.Contains(data, "re-login") ||
+		strings.Contains(data, "session terminated") ||
+		strings.Contains(data, "not authorised") ||
+		strings.Contains(data, "not authorized")
+}
+
+// relogin - Calls Login with credentials from api.Credentials, unless auth token was already changed
+// from auth (expired one) by concurrent call.
+func (api *API) relogin(ctx context.Context, auth string) (err error) {
+	api.reloginM.Lock()
+	defer api.reloginM.Unlock()
+
+	if api.Auth() != auth {
+		return
+	}
+	user, password, err := api.Credentials.Credentials(ctx)
+	if err != nil {
+		return
+	}
+	api.printf("Session expired, re-login as %s", user)
+	_, err = api.LoginContext(ctx, user, password)
+	return
+}
+
+// isToken - Returns true if auth is API token set by SetAuth() rather than session Id filled by Login().
+// Before Zabbix 5.4 there are no API tokens, so auth set by SetAuth() is a session Id too.
+func (api *API) isToken(ctx context.Context) (token bool, caps Capabilities, err error) {
+	api.authM.RLock()
+	session := api.session
+	api.authM.RUnlock()
+	if session {
+		return
+	}
+
+	if caps, err = api.CapabilitiesContext(ctx); err != nil {
+		return
+	}
+	token = caps.TokenAuth
+	return
+}
+
+// Logout - Calls "user.logout" API method and clears auth token.
+// Does nothing if there is no auth token. API tokens can not be logged out, error is returned for them.
+func (api *API) Logout() (err error) {
+	return api.LogoutContext(context.Background())
+}
+
+// LogoutContext - Same as Logout, but bound to ctx.
+func (api *API) LogoutContext(ctx context.Context) (err error) {
+	auth := api.Auth()
+	if auth == "" {
+		return
+	}
+	token, _, err := api.isToken(ctx)
+	if err != nil {
+		return
+	}
+	if token {
+		err = errors.New("API token can not be logged out.")
+		return
+	}
+
+	_, err = api.CallWithErrorContext(ctx, "user.logout", []string{})
+	if err != nil {
+		return
+	}
+
+	api.authM.Lock()
+	if api.auth == auth {
+		api.auth, api.session = "", false
+	}
+	api.authM.Unlock()
+	return
+}
+
+// CheckAuthentication - Wrapper for user.checkAuthentication: https://www.zabbix.com/documentation/3.0/manual/api/reference/user/checkauthentication
+// Returns info about current session or error if it is not valid.
+// API tokens are checked with "token" param, which requires Zabbix 6.4+.
+func (api *API) CheckAuthentication() (res *Session, err error) {
+	return api.CheckAuthenticationContext(context.Background())
+}
+
+// CheckAuthenticationContext - Same as CheckAuthentication, but bound to ctx.
+func (api *API) CheckAuthenticationContext(ctx context.Context) (res *Session, err error) {
+	token, caps, err := api.isToken(ctx)
+	if err != nil {
+		return
+	}
+	params := Params{"sessionid": api.Auth()}
+	if token {
+		// "token" param was added together with header auth
+		if !caps.AuthHeader {
+			err = errors.New("Checking API token requires Zabbix 6.4 or later.")
+			return
+		}
+		params = Params{"token": api.Auth()}
+	}
+
+	// session Id or token is passed as parameter, method is called without auth
+	response, err := api.call(withRawResult(ctx), "user.checkAuthentication", params, "")
+	if err == nil && response.Error != nil {
+		err = response.Error
+	}
+	if err != nil {
+		return
+	}
+
+	res = new(Session)
+	if err = decodeResult("user.checkAuthentication", response.Result, res); err != nil {
+		res = nil
+	}
+	return
+}
+
+// Close - Logs out if auth token was filled by Login(). API tokens are left intact.
+// API may be used again after Login().
+func (api *API) Close() (err error) {
+	api.authM.RLock()
+	session := api.session
+	api.authM.RUnlock()
+
+	if session {
+		err = api.Logout()
+	}
+	return
+}
@@ -0,0 +1,193 @@
+package zabbix
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestRelogin(t *testing.T) {
+	var logins int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req request
+		json.NewDecoder(r.Body).Decode(&req)
+		switch {
+		case req.Method == "user.login":
+			atomic.AddInt32(&logins, 1)
+			fmt.Fprintf(w, `{"jsonrpc":"2.0","result":"fresh","id":%d}`, req.ID)
+		case req.Auth != "fresh":
+			fmt.Fprintf(w, `{"jsonrpc":"2.0","error":{"code":-32602,"message":"Invalid params.",`+
+				`"data":"Session terminated, re-login, please."},"id":%d}`, req.ID)
+		default:
+			fmt.Fprintf(w, `{"jsonrpc":"2.0","result":[],"id":%d}`, req.ID)
+		}
+	}))
+	defer srv.Close()
+
+	api := NewAPI(srv.URL)
+	api.AuthMode = AuthBody
+	api.SetAuth("expired")
+	if _, err := api.CallWithError("host.get", Params{}); err == nil {
+		t.Fatal("Expected error without credentials")
+	}
+
+	api.Credentials = &Credentials{User: "user", Password: "password"}
+	if _, err := api.CallWithError("host.get", Params{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := api.CallWithError("host.get", Params{}); err != nil {
+		t.Fatal(err)
+	}
+	if logins != 1 || api.Auth() != "fresh" {
+		t.Errorf("Expected 1 login, got %d (auth %q)", logins, api.Auth())
+	}
+}
+
+func TestConcurrentAuth(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req request
+		json.NewDecoder(r.Body).Decode(&req)
+		switch req.Method {
+		case "user.login":
+			fmt.Fprintf(w, `{"jsonrpc":"2.0","result":"session%d","id":%d}`, req.ID, req.ID)
+		case "APIInfo.version":
+			if req.Auth != "" {
+				t.Errorf("Auth passed to APIInfo.version: %q", req.Auth)
+			}
+			fmt.Fprintf(w, `{"jsonrpc":"2.0","result":"3.0.0","id":%d}`, req.ID)
+		default:
+			fmt.Fprintf(w, `{"jsonrpc":"2.0","result":[],"id":%d}`, req.ID)
+		}
+	}))
+	defer srv.Close()
+
+	api := NewAPI(srv.URL)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			if _, err := api.Login("user", "password"); err != nil {
+				t.Error(err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			if _, err := api.Version(); err != nil {
+				t.Error(err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			if _, err := api.CallWithError("host.get", Params{}); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestClose(t *testing.T) {
+	var logouts int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req request
+		json.NewDecoder(r.Body).Decode(&req)
+		switch req.Method {
+		case "user.login":
+			fmt.Fprintf(w, `{"jsonrpc":"2.0","result":"session","id":%d}`, req.ID)
+		case "APIInfo.version":
+			fmt.Fprintf(w, `{"jsonrpc":"2.0","result":"3.0.0","id":%d}`, req.ID)
+		case "user.logout":
+			atomic.AddInt32(&logouts, 1)
+			fmt.Fprintf(w, `{"jsonrpc":"2.0","result":true,"id":%d}`, req.ID)
+		}
+	}))
+	defer srv.Close()
+
+	api := NewAPIWithToken(srv.URL, "token")
+	if err := api.Close(); err != nil || logouts != 0 {
+		t.Fatalf("API token should not be logged out: %v, %d", err, logouts)
+	}
+
+	api = NewAPI(srv.URL)
+	if _, err := api.Login("user", "password"); err != nil {
+		t.Fatal(err)
+	}
+	if err := api.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if logouts != 1 || api.Auth() != "" {
+		t.Errorf("Expected logout, got %d (auth %q)", logouts, api.Auth())
+	}
+}
+
+func TestCheckAuthentication(t *testing.T) {
+	for _, c := range []struct {
+		version string
+		token   bool
+		param   string // expected param, "" - error without call
+	}{
+		{"6.4.0", false, "sessionid"},
+		{"6.4.0", true, "token"},
+		{"6.0.0", true, ""},
+		{"5.0.0", true, "sessionid"}, // no API tokens before 5.4, auth is session Id
+	} {
+		var params map[string]string
+		var logouts int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var req struct {
+				request
+				Params json.RawMessage `json:"params"`
+			}
+			json.NewDecoder(r.Body).Decode(&req)
+			switch req.Method {
+			case "user.login":
+				fmt.Fprintf(w, `{"jsonrpc":"2.0","result":"session","id":%d}`, req.ID)
+			case "APIInfo.version":
+				fmt.Fprintf(w, `{"jsonrpc":"2.0","result":%q,"id":%d}`, c.version, req.ID)
+			case "user.checkAuthentication":
+				params = nil
+				json.Unmarshal(req.Params, &params)
+				if req.Auth != "" || r.Header.Get("Authorization") != "" {
+					t.Errorf("%s: auth passed to user.checkAuthentication", c.version)
+				}
+				fmt.Fprintf(w, `{"jsonrpc":"2.0","result":{"userid":"1","sessionid":"session"},"id":%d}`, req.ID)
+			case "user.logout":
+				atomic.AddInt32(&logouts, 1)
+				fmt.Fprintf(w, `{"jsonrpc":"2.0","result":true,"id":%d}`, req.ID)
+			}
+		}))
+
+		api := NewAPI(srv.URL)
+		if c.token {
+			api.SetAuth("secret")
+		} else if _, err := api.Login("user", "password"); err != nil {
+			t.Fatal(err)
+		}
+		s, err := api.CheckAuthentication()
+		switch {
+		case c.param == "":
+			if err == nil || params != nil {
+				t.Errorf("%s: expected error without call, got %v, %v", c.version, err, params)
+			}
+		case err != nil:
+			t.Errorf("%s: %v", c.version, err)
+		case len(params) != 1 || params[c.param] != api.Auth() || s.UserID != "1":
+			t.Errorf("%s: expected %s param, got %v (%+v)", c.version, c.param, params, s)
+		}
+
+		err = api.Logout()
+		isToken := c.token && c.version != "5.0.0"
+		if isToken && (err == nil || logouts != 0 || api.Auth() != "secret") {
+			t.Errorf("%s: API token should not be logged out: %v, %d", c.version, err, logouts)
+		}
+		if !isToken && (err != nil || logouts != 1 || api.Auth() != "") {
+			t.Errorf("%s: expected logout: %v, %d", c.version, err, logouts)
+		}
+		srv.Close()
+	}
+}
@@ -0,0 +1,50 @@
+package zabbix
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// withoutFields - Converts objects to array of maps and removes fields from every map.
+// It is used to shape create/update params for server version.
+func withoutFields(objects interface{}, fields ...string) (res []map[string]interface{}, err error) {
+	b, err := json.Marshal(objects)
+	if err != nil {
+		return
+	}
+
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+	if err = d.Decode(&res); err != nil {
+		return
+	}
+	for _, m := range res {
+		for _, f := range fields {
+			delete(m, f)
+		}
+	}
+	return
+}
+
+// withNested - Replaces field of every map in res with params returned by nested for object with same index.
+// Maps without field are left as is. It is used to shape nested objects, like host interfaces.
+func withNested(res []map[string]interface{}, field string, nested func(i int) ([]map[string]interface{}, error)) (err error) {
+	for i, m := range res {
+		if _, present := m[field]; !present {
+			continue
+		}
+		if m[field], err = nested(i); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// idObjects - Converts Ids to array of objects with single key field, as used by many create and mass update params.
+func idObjects(key string, ids []string) (res []map[string]string) {
+	res = make([]map[string]string, len(ids))
+	for i, id := range ids {
+		res[i] = map[string]string{key: id}
+	}
+	return
+}
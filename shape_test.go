@@ -0,0 +1,75 @@
+package zabbix
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestItemsParams(t *testing.T) {
+	items := Items{{
+		Key:            "key",
+		DataType:       Hexadecimal,
+		ApplicationIds: []string{"1"},
+		Preprocessing:  ItemPreprocessings{{Type: PreprocessingHexToDecimal}},
+		Tags:           Tags{{Tag: "component", Value: "cpu"}},
+	}}
+
+	for version, expected := range map[string]string{
+		"2.2.0": `[{"applications":["1"],"data_type":2,"delay":0,"delta":0,"description":"","hostid":"","key_":"key","name":"","type":0,"value_type":0}]`,
+		"3.4.0": `[{"applications":["1"],"delay":0,"description":"","hostid":"","key_":"key","name":"","preprocessing":[{"params":"","type":8}],"type":0,"value_type":0}]`,
+		"6.0.0": `[{"delay":0,"description":"","hostid":"","key_":"key","name":"","preprocessing":[{"error_handler":0,"error_handler_params":"","params":"","type":8}],"tags":[{"tag":"component","value":"cpu"}],"type":0,"value_type":0}]`,
+	} {
+		v, _ := ParseVersion(version)
+		params, err := items.params(v.Capabilities())
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, _ := json.Marshal(params)
+		if string(b) != expected {
+			t.Errorf("%s:\nexpected %s\ngot      %s", version, expected, b)
+		}
+	}
+
+	snmp := Items{{Key: "ifInOctets", Type: SNMPv2Agent}, {Key: "trap", Type: SNMPTrap}}
+	for version, expected := range map[string][]string{"4.0.0": {"4", "17"}, "5.0.0": {"20", "17"}} {
+		v, _ := ParseVersion(version)
+		params, err := snmp.params(v.Capabilities())
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, e := range expected {
+			if actual := fmt.Sprint(params[i]["type"]); actual != e {
+				t.Errorf("%s: expected type %s, got %s", version, e, actual)
+			}
+		}
+	}
+}
+
+func TestLoginUsername(t *testing.T) {
+	for version, expected := range map[string]string{"3.0.0": "user", "6.4.0": "username"} {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var req struct {
+				request
+				Params map[string]string `json:"params"`
+			}
+			json.NewDecoder(r.Body).Decode(&req)
+			if req.Method == "APIInfo.version" {
+				fmt.Fprintf(w, `{"jsonrpc":"2.0","result":%q,"id":%d}`, version, req.ID)
+				return
+			}
+			if req.Params[expected] != "Admin" {
+				t.Errorf("%s: expected %q param, got %v", version, expected, req.Params)
+			}
+			fmt.Fprintf(w, `{"jsonrpc":"2.0","result":"session","id":%d}`, req.ID)
+		}))
+
+		_, err := NewAPI(srv.URL).Login("Admin", "zabbix")
+		srv.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
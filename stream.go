@@ -0,0 +1,156 @@
+package zabbix
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+	"time"
+)
+
+// CallEach - Calls specified API method, which should return array, and passes its elements to fn one by one,
+// without reading whole response into memory. If fn returns error, call is stopped and error is returned.
+// API error is returned as *Error. Like Call, it re-logins if session expired, but interceptors and retries
+// are not used, as elements may be already passed to fn.
+func (api *API) CallEach(method string, params interface{}, fn func(element json.RawMessage) error) (err error) {
+	return api.CallEachContext(context.Background(), method, params, fn)
+}
+
+// CallEachContext - Same as CallEach, but bound to ctx.
+func (api *API) CallEachContext(ctx context.Context, method string, params interface{}, fn func(element json.RawMessage) error) (err error) {
+	return api.stream(ctx, method, params, func(d *json.Decoder) (err error) {
+		var element json.RawMessage
+		if err = d.Decode(&element); err != nil {
+			return
+		}
+		return fn(element)
+	})
+}
+
+// streamEach - Calls get method with output defaulting to "extend", decodes elements of result to T
+// and passes them to fn one by one.
+func streamEach[T any](ctx context.Context, api *API, method string, params Params, fn func(object *T) error) (err error) {
+	if _, present := params["output"]; !present {
+		params["output"] = "extend"
+	}
+	return api.stream(ctx, method, params, func(d *json.Decoder) (err error) {
+		var object T
+		if err = d.Decode(&object); err != nil {
+			return &UnexpectedResult{Method: method, Err: err}
+		}
+		return fn(&object)
+	})
+}
+
+// stream - Calls method and decodes elements of result array with decode one by one.
+// decode should decode exactly one value.
+func (api *API) stream(ctx context.Context, method string, params interface{}, decode func(d *json.Decoder) error) (err error) {
+	auth := api.Auth()
+	err = api.streamOnce(ctx, method, params, auth, decode)
+	if e, ok := err.(*Error); ok && api.Credentials != nil && IsSessionExpired(e) {
+		// API error is returned before any result element, so it is safe to replay
+		if err = api.relogin(ctx, auth); err == nil {
+			err = api.streamOnce(ctx, method, params, api.Auth(), decode)
+		}
+	}
+	return
+}
+
+func (api *API) streamOnce(ctx context.Context, method string, params interface{}, auth string, decode func(d *json.Decoder) error) (err error) {
+	id, body, headerAuth, err := api.marshalRequest(ctx, method, params, auth)
+	if err != nil {
+		return
+	}
+	api.logRequest(ctx, method, id, body)
+
+	release, err := api.acquire(ctx, method)
+	if err != nil {
+		return
+	}
+	defer release()
+
+	a := &Attempt{Method: method, Number: 1}
+	start := time.Now()
+	res, err := api.do(ctx, body, headerAuth)
+	if err != nil {
+		a.Err = err
+		api.logResponse(ctx, a, id, time.Since(start), len(body), nil)
+		return
+	}
+	defer res.Body.Close()
+	a.StatusCode = res.StatusCode
+
+	r := bufio.NewReader(res.Body)
+	head, _ := r.Peek(maxSnippet)
+	if a.StatusCode < 200 || a.StatusCode > 299 || !isJSON(res.Header.Get("Content-Type"), head) {
+		b, _ := ioutil.ReadAll(io.LimitReader(r, maxSnippet+1))
+		err = newHTTPError(res, b)
+		api.logResponse(ctx, a, id, time.Since(start), len(body), b)
+		return
+	}
+	api.logResponse(ctx, a, id, time.Since(start), len(body), nil)
+
+	return decodeStream(method, json.NewDecoder(r), decode)
+}
+
+// decodeStream - Reads JSON-RPC response from d, passing d to decode for every element of result array.
+func decodeStream(method string, d *json.Decoder, decode func(d *json.Decoder) error) (err error) {
+	if err = expectDelim(d, '{'); err != nil {
+		return
+	}
+	for d.More() {
+		var key json.Token
+		if key, err = d.Token(); err != nil {
+			return
+		}
+
+		switch key {
+		case "error":
+			var e *Error
+			if err = d.Decode(&e); err != nil {
+				return
+			}
+			if e != nil {
+				return e
+			}
+		case "result":
+			var t json.Token
+			if t, err = d.Token(); err != nil {
+				return
+			}
+			if t != json.Delim('[') {
+				return &UnexpectedResult{Method: method, Result: t, Err: errors.New("Expected array.")}
+			}
+			for d.More() {
+				if err = decode(d); err != nil {
+					return
+				}
+			}
+			if err = expectDelim(d, ']'); err != nil {
+				return
+			}
+		default:
+			var skip json.RawMessage
+			if err = d.Decode(&skip); err != nil {
+				return
+			}
+		}
+	}
+	return expectDelim(d, '}')
+}
+
+// expectDelim - Reads next token from d and checks that it is delim.
+func expectDelim(d *json.Decoder, delim json.Delim) error {
+	t, err := d.Token()
+	if err != nil {
+		return err
+	}
+	if t != delim {
+		return fmt.Errorf("Expected %s, got %s.", delim, strings.TrimSpace(fmt.Sprint(t)))
+	}
+	return nil
+}
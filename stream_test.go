@@ -0,0 +1,84 @@
+package zabbix
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestHistorysGetEach(t *testing.T) {
+	const n = 10000
+	var b strings.Builder
+	b.WriteString("[")
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		fmt.Fprintf(&b, `{"itemid":"%d","clock":"1500000000","value":"1"}`, i)
+	}
+	b.WriteString("]")
+
+	api := newResultAPI(b.String())
+	count := 0
+	err := api.HistorysGetEach(Params{}, func(h *History) error {
+		if h.ItemID != fmt.Sprint(count) {
+			t.Fatalf("expected itemid %d, got %s", count, h.ItemID)
+		}
+		count++
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != n {
+		t.Errorf("expected %d records, got %d", n, count)
+	}
+
+	stop := errors.New("stop")
+	count = 0
+	err = api.HistorysGetEach(Params{}, func(h *History) error {
+		count++
+		if count == 3 {
+			return stop
+		}
+		return nil
+	})
+	if err != stop || count != 3 {
+		t.Errorf("expected stop after 3 records, got %v after %d", err, count)
+	}
+}
+
+func TestCallEach(t *testing.T) {
+	api := newResultAPI(`"Session terminated, re-login."`)
+	err := api.CallEach("history.get", Params{}, func(element json.RawMessage) error { return nil })
+	var unexpected *UnexpectedResult
+	if !errors.As(err, &unexpected) || unexpected.Method != "history.get" {
+		t.Errorf("expected UnexpectedResult for non-array result, got %v", err)
+	}
+
+	api = newResultAPI(`[]`)
+	called := false
+	err = api.CallEach("history.get", Params{}, func(element json.RawMessage) error {
+		called = true
+		return nil
+	})
+	if err != nil || called {
+		t.Errorf("expected no elements, got %v, %v", called, err)
+	}
+
+	for body, expected := range map[string]int{
+		`{"jsonrpc":"2.0","error":{"code":-32602,"message":"Invalid params.","data":"No permissions."},"id":1}`: CodeInvalidParams,
+		`{"jsonrpc":"2.0","id":1,"error":{"code":-32500,"message":"Application error.","data":""}}`:             CodeAppError,
+	} {
+		var e *Error
+		err = decodeStream("history.get", json.NewDecoder(strings.NewReader(body)), func(d *json.Decoder) error {
+			t.Error("unexpected element")
+			return nil
+		})
+		if !errors.As(err, &e) || e.Code != expected {
+			t.Errorf("%s: expected API error %d, got %v", body, expected, err)
+		}
+	}
+}
@@ -0,0 +1,10 @@
+package zabbix
+
+// Tag - object tag: https://www.zabbix.com/documentation/5.4/manual/api/reference/item/object#item-tag
+type Tag struct {
+	Tag   string `json:"tag"`
+	Value string `json:"value"`
+}
+
+// Tags - the array of Tag
+type Tags []Tag
@@ -1,12 +1,313 @@
 package zabbix
 
-// Template - zabbix template interface
+import (
+	"context"
+)
+
+// TemplateID - template id
+type TemplateID struct {
+	TemplateID string `json:"templateid"`
+}
+
+// TemplateIds - template ids
+type TemplateIds []TemplateID
+
+// Template - https://www.zabbix.com/documentation/3.0/manual/api/reference/template/object
 type Template struct {
-	ID          string `json:"templateid"`
+	ID          string `json:"templateid,omitempty"`
 	Host        string `json:"host"`
 	Description string `json:"description"`
 	Name        string `json:"name"`
+
+	Tags Tags `json:"tags,omitempty"` // 4.2+
+
+	// Fields below used only when creating and updating templates
+	GroupIds       HostGroupIds `json:"groups,omitempty"`          // template groups in 6.2+
+	Templates      TemplateIds  `json:"templates,omitempty"`       // templates to link
+	TemplatesClear TemplateIds  `json:"templates_clear,omitempty"` // templates to unlink and clear, update only
+	Macros         UserMacros   `json:"macros,omitempty"`
+
+	// Fields below are returned with selectParentTemplates
+	ParentTemplates Templates `json:"parentTemplates,omitempty"`
 }
 
 // Templates - the array of template
 type Templates []Template
+
+// params - Returns templates as create/update params for server with capabilities c: removed and read-only fields are omitted,
+// nested macros are shaped too.
+func (templates Templates) params(c Capabilities) (res []map[string]interface{}, err error) {
+	fields := []string{"parentTemplates"}
+	if !c.HostTags {
+		fields = append(fields, "tags")
+	}
+	if res, err = withoutFields(templates, fields...); err != nil {
+		return
+	}
+	err = withNested(res, "macros", func(i int) ([]map[string]interface{}, error) { return macroParams(templates[i].Macros, c) })
+	return
+}
+
+// templates - Returns Resource for template methods.
+func (api *API) templates() *Resource[Template] {
+	r := NewResource(api, "template", "templateid", func(t *Template) *string { return &t.ID })
+	r.Params = func(templates []Template, c Capabilities) ([]map[string]interface{}, error) {
+		return Templates(templates).params(c)
+	}
+	return r
+}
+
+// TemplatesGet - Wrapper for template.get: https://www.zabbix.com/documentation/3.0/manual/api/reference/template/get
+func (api *API) TemplatesGet(params Params) (res Templates, err error) {
+	return api.TemplatesGetContext(context.Background(), params)
+}
+
+// TemplatesGetContext - Same as TemplatesGet, but bound to ctx.
+func (api *API) TemplatesGetContext(ctx context.Context, params Params) (res Templates, err error) {
+	res, err = api.templates().GetContext(ctx, params)
+	return
+}
+
+// TemplatesGetByHostIds - Gets templates linked to hosts.
+func (api *API) TemplatesGetByHostIds(ids []string) (res Templates, err error) {
+	return api.TemplatesGetByHostIdsContext(context.Background(), ids)
+}
+
+// TemplatesGetByHostIdsContext - Same as TemplatesGetByHostIds, but bound to ctx.
+func (api *API) TemplatesGetByHostIdsContext(ctx context.Context, ids []string) (res Templates, err error) {
+	return api.TemplatesGetContext(ctx, Params{"hostids": ids})
+}
+
+// TemplateGetByID - Gets template by Id only if there is exactly 1 matching template. Linked templates are selected too.
+func (api *API) TemplateGetByID(id string) (res *Template, err error) {
+	return api.TemplateGetByIDContext(context.Background(), id)
+}
+
+// TemplateGetByIDContext - Same as TemplateGetByID, but bound to ctx.
+func (api *API) TemplateGetByIDContext(ctx context.Context, id string) (res *Template, err error) {
+	return api.templates().GetOneContext(ctx, Params{"templateids": id, "selectParentTemplates": "extend"})
+}
+
+// TemplateGetByHost - Gets template by technical name only if there is exactly 1 matching template.
+func (api *API) TemplateGetByHost(host string) (res *Template, err error) {
+	return api.TemplateGetByHostContext(context.Background(), host)
+}
+
+// TemplateGetByHostContext - Same as TemplateGetByHost, but bound to ctx.
+func (api *API) TemplateGetByHostContext(ctx context.Context, host string) (res *Template, err error) {
+	return api.templates().GetOneContext(ctx, Params{"filter": map[string]string{"host": host}})
+}
+
+// TemplatesCreate - Wrapper for template.create: https://www.zabbix.com/documentation/3.0/manual/api/reference/template/create
+// Fields not supported by server version are omitted; if version is unknown, templates are sent as is.
+func (api *API) TemplatesCreate(templates Templates) (err error) {
+	return api.TemplatesCreateContext(context.Background(), templates)
+}
+
+// TemplatesCreateContext - Same as TemplatesCreate, but bound to ctx.
+func (api *API) TemplatesCreateContext(ctx context.Context, templates Templates) (err error) {
+	return api.templates().CreateContext(ctx, templates)
+}
+
+// TemplatesUpdate - Wrapper for template.update: https://www.zabbix.com/documentation/3.0/manual/api/reference/template/update
+// Fields not supported by server version are omitted; if version is unknown, templates are sent as is.
+func (api *API) TemplatesUpdate(templates Templates) (err error) {
+	return api.TemplatesUpdateContext(context.Background(), templates)
+}
+
+// TemplatesUpdateContext - Same as TemplatesUpdate, but bound to ctx.
+func (api *API) TemplatesUpdateContext(ctx context.Context, templates Templates) (err error) {
+	return api.templates().UpdateContext(ctx, templates)
+}
+
+// TemplatesDelete - Wrapper for template.delete: https://www.zabbix.com/documentation/3.0/manual/api/reference/template/delete
+// Cleans ID in all templates elements if call succeed.
+func (api *API) TemplatesDelete(templates Templates) (err error) {
+	return api.TemplatesDeleteContext(context.Background(), templates)
+}
+
+// TemplatesDeleteContext - Same as TemplatesDelete, but bound to ctx.
+func (api *API) TemplatesDeleteContext(ctx context.Context, templates Templates) (err error) {
+	return api.templates().DeleteContext(ctx, templates)
+}
+
+// TemplatesDeleteByIds - Wrapper for template.delete: https://www.zabbix.com/documentation/3.0/manual/api/reference/template/delete
+func (api *API) TemplatesDeleteByIds(ids []string) (err error) {
+	return api.TemplatesDeleteByIdsContext(context.Background(), ids)
+}
+
+// TemplatesDeleteByIdsContext - Same as TemplatesDeleteByIds, but bound to ctx.
+func (api *API) TemplatesDeleteByIdsContext(ctx context.Context, ids []string) (err error) {
+	return api.templates().DeleteByIdsContext(ctx, ids)
+}
+
+// templateGroups - Returns Resource for templategroup methods for Zabbix 6.2+, or for hostgroup methods
+// for older versions and unknown version, as templates were in host groups before 6.2.
+func (api *API) templateGroups(ctx context.Context) *Resource[HostGroup] {
+	prefix := "hostgroup"
+	if caps, err := api.CapabilitiesContext(ctx); err == nil && caps.TemplateGroups {
+		prefix = "templategroup"
+	}
+	return NewResource(api, prefix, "groupid", func(g *HostGroup) *string { return &g.ID })
+}
+
+// TemplateGroupsGet - Wrapper for templategroup.get (hostgroup.get before 6.2): https://www.zabbix.com/documentation/6.2/manual/api/reference/templategroup/get
+func (api *API) TemplateGroupsGet(params Params) (res HostGroups, err error) {
+	return api.TemplateGroupsGetContext(context.Background(), params)
+}
+
+// TemplateGroupsGetContext - Same as TemplateGroupsGet, but bound to ctx.
+func (api *API) TemplateGroupsGetContext(ctx context.Context, params Params) (res HostGroups, err error) {
+	res, err = api.templateGroups(ctx).GetContext(ctx, params)
+	return
+}
+
+// TemplateGroupsCreate - Wrapper for templategroup.create (hostgroup.create before 6.2): https://www.zabbix.com/documentation/6.2/manual/api/reference/templategroup/create
+func (api *API) TemplateGroupsCreate(groups HostGroups) (err error) {
+	return api.TemplateGroupsCreateContext(context.Background(), groups)
+}
+
+// TemplateGroupsCreateContext - Same as TemplateGroupsCreate, but bound to ctx.
+func (api *API) TemplateGroupsCreateContext(ctx context.Context, groups HostGroups) (err error) {
+	return api.templateGroups(ctx).CreateContext(ctx, groups)
+}
+
+// TemplateGroupsDelete - Wrapper for templategroup.delete (hostgroup.delete before 6.2): https://www.zabbix.com/documentation/6.2/manual/api/reference/templategroup/delete
+// Cleans ID in all groups elements if call succeed.
+func (api *API) TemplateGroupsDelete(groups HostGroups) (err error) {
+	return api.TemplateGroupsDeleteContext(context.Background(), groups)
+}
+
+// TemplateGroupsDeleteContext - Same as TemplateGroupsDelete, but bound to ctx.
+func (api *API) TemplateGroupsDeleteContext(ctx context.Context, groups HostGroups) (err error) {
+	return api.templateGroups(ctx).DeleteContext(ctx, groups)
+}
+
+// TemplateGroupsDeleteByIds - Wrapper for templategroup.delete (hostgroup.delete before 6.2): https://www.zabbix.com/documentation/6.2/manual/api/reference/templategroup/delete
+func (api *API) TemplateGroupsDeleteByIds(ids []string) (err error) {
+	return api.TemplateGroupsDeleteByIdsContext(context.Background(), ids)
+}
+
+// TemplateGroupsDeleteByIdsContext - Same as TemplateGroupsDeleteByIds, but bound to ctx.
+func (api *API) TemplateGroupsDeleteByIdsContext(ctx context.Context, ids []string) (err error) {
+	return api.templateGroups(ctx).DeleteByIdsContext(ctx, ids)
+}
+
+// TemplateMassAdd - template.massadd params
+type TemplateMassAdd struct {
+	TemplateIds     []string // templates to update
+	GroupIds        []string // groups to add templates to
+	HostIds         []string // hosts to link templates to, removed in newer versions, use TemplatesLink
+	LinkTemplateIds []string // templates to link to templates
+}
+
+// params - Returns template.massadd params, empty fields are omitted.
+func (m TemplateMassAdd) params() Params {
+	params := Params{"templates": idObjects("templateid", m.TemplateIds)}
+	if len(m.GroupIds) != 0 {
+		params["groups"] = idObjects("groupid", m.GroupIds)
+	}
+	if len(m.HostIds) != 0 {
+		params["hosts"] = idObjects("hostid", m.HostIds)
+	}
+	if len(m.LinkTemplateIds) != 0 {
+		params["templates_link"] = idObjects("templateid", m.LinkTemplateIds)
+	}
+	return params
+}
+
+// TemplateMassRemove - template.massremove params
+type TemplateMassRemove struct {
+	TemplateIds       []string // templates to update
+	GroupIds          []string // groups to remove templates from
+	HostIds           []string // hosts to unlink templates from
+	UnlinkTemplateIds []string // templates to unlink from templates
+	ClearTemplateIds  []string // templates to unlink and clear from templates
+}
+
+// params - Returns template.massremove params, empty fields are omitted.
+func (m TemplateMassRemove) params() Params {
+	params := Params{"templateids": m.TemplateIds}
+	for key, ids := range map[string][]string{
+		"groupids":          m.GroupIds,
+		"hostids":           m.HostIds,
+		"templateids_link":  m.UnlinkTemplateIds,
+		"templateids_clear": m.ClearTemplateIds,
+	} {
+		if len(ids) != 0 {
+			params[key] = ids
+		}
+	}
+	return params
+}
+
+// TemplatesMassAdd - Wrapper for template.massadd: https://www.zabbix.com/documentation/3.0/manual/api/reference/template/massadd
+func (api *API) TemplatesMassAdd(m TemplateMassAdd) (err error) {
+	return api.TemplatesMassAddContext(context.Background(), m)
+}
+
+// TemplatesMassAddContext - Same as TemplatesMassAdd, but bound to ctx.
+func (api *API) TemplatesMassAddContext(ctx context.Context, m TemplateMassAdd) (err error) {
+	return api.massUpdate(ctx, "template.massadd", m.params(), "templateids", len(m.TemplateIds))
+}
+
+// TemplatesMassRemove - Wrapper for template.massremove: https://www.zabbix.com/documentation/3.0/manual/api/reference/template/massremove
+func (api *API) TemplatesMassRemove(m TemplateMassRemove) (err error) {
+	return api.TemplatesMassRemoveContext(context.Background(), m)
+}
+
+// TemplatesMassRemoveContext - Same as TemplatesMassRemove, but bound to ctx.
+func (api *API) TemplatesMassRemoveContext(ctx context.Context, m TemplateMassRemove) (err error) {
+	return api.massUpdate(ctx, "template.massremove", m.params(), "templateids", len(m.TemplateIds))
+}
+
+// TemplatesLink - Links templates to hosts with host.massadd: https://www.zabbix.com/documentation/3.0/manual/api/reference/host/massadd
+func (api *API) TemplatesLink(templateIds, hostIds []string) (err error) {
+	return api.TemplatesLinkContext(context.Background(), templateIds, hostIds)
+}
+
+// TemplatesLinkContext - Same as TemplatesLink, but bound to ctx.
+func (api *API) TemplatesLinkContext(ctx context.Context, templateIds, hostIds []string) (err error) {
+	params := Params{"hosts": idObjects("hostid", hostIds), "templates": idObjects("templateid", templateIds)}
+	return api.massUpdate(ctx, "host.massadd", params, "hostids", len(hostIds))
+}
+
+// TemplatesUnlink - Unlinks templates from hosts with host.massremove. If clear is true,
+// entities inherited from templates are removed from hosts too, otherwise they are kept as not templated.
+func (api *API) TemplatesUnlink(templateIds, hostIds []string, clear bool) (err error) {
+	return api.TemplatesUnlinkContext(context.Background(), templateIds, hostIds, clear)
+}
+
+// TemplatesUnlinkContext - Same as TemplatesUnlink, but bound to ctx.
+func (api *API) TemplatesUnlinkContext(ctx context.Context, templateIds, hostIds []string, clear bool) (err error) {
+	params := Params{"hostids": hostIds, "templateids": templateIds}
+	if clear {
+		params = Params{"hostids": hostIds, "templateids_clear": templateIds}
+	}
+	return api.massUpdate(ctx, "host.massremove", params, "hostids", len(hostIds))
+}
+
+// TemplatesLinkTemplates - Links parent templates to templates, making nested templates.
+func (api *API) TemplatesLinkTemplates(templateIds, parentIds []string) (err error) {
+	return api.TemplatesLinkTemplatesContext(context.Background(), templateIds, parentIds)
+}
+
+// TemplatesLinkTemplatesContext - Same as TemplatesLinkTemplates, but bound to ctx.
+func (api *API) TemplatesLinkTemplatesContext(ctx context.Context, templateIds, parentIds []string) (err error) {
+	return api.TemplatesMassAddContext(ctx, TemplateMassAdd{TemplateIds: templateIds, LinkTemplateIds: parentIds})
+}
+
+// TemplatesUnlinkTemplates - Unlinks parent templates from templates. If clear is true,
+// entities inherited from parent templates are removed too.
+func (api *API) TemplatesUnlinkTemplates(templateIds, parentIds []string, clear bool) (err error) {
+	return api.TemplatesUnlinkTemplatesContext(context.Background(), templateIds, parentIds, clear)
+}
+
+// TemplatesUnlinkTemplatesContext - Same as TemplatesUnlinkTemplates, but bound to ctx.
+func (api *API) TemplatesUnlinkTemplatesContext(ctx context.Context, templateIds, parentIds []string, clear bool) (err error) {
+	m := TemplateMassRemove{TemplateIds: templateIds, UnlinkTemplateIds: parentIds}
+	if clear {
+		m = TemplateMassRemove{TemplateIds: templateIds, ClearTemplateIds: parentIds}
+	}
+	return api.TemplatesMassRemoveContext(ctx, m)
+}
@@ -0,0 +1,169 @@
+package zabbix
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func CreateTemplate(group *HostGroup, t *testing.T) *Template {
+	name := fmt.Sprintf("Template %s-%d", getHost(), rand.Int())
+	templates := Templates{{
+		Host:     name,
+		Name:     "Name for " + name,
+		GroupIds: HostGroupIds{{group.ID}},
+	}}
+	err := getAPI(t).TemplatesCreate(templates)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &templates[0]
+}
+
+func DeleteTemplate(template *Template, t *testing.T) {
+	err := getAPI(t).TemplatesDelete(Templates{*template})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTemplates(t *testing.T) {
+	api := getAPI(t)
+
+	group := CreateHostGroup(t)
+	defer DeleteHostGroup(group, t)
+
+	host := CreateHost(group, t)
+	defer DeleteHost(host, t)
+
+	template := CreateTemplate(group, t)
+	defer DeleteTemplate(template, t)
+
+	parent := CreateTemplate(group, t)
+	defer DeleteTemplate(parent, t)
+
+	if err := api.TemplatesLinkTemplates([]string{template.ID}, []string{parent.ID}); err != nil {
+		t.Fatal(err)
+	}
+	got, err := api.TemplateGetByID(template.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.ParentTemplates) != 1 || got.ParentTemplates[0].ID != parent.ID {
+		t.Errorf("Bad parent templates: %#v", got.ParentTemplates)
+	}
+	if err = api.TemplatesUnlinkTemplates([]string{template.ID}, []string{parent.ID}, true); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = api.TemplatesLink([]string{template.ID}, []string{host.ID}); err != nil {
+		t.Fatal(err)
+	}
+	templates, err := api.TemplatesGetByHostIds([]string{host.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(templates) != 1 || templates[0].ID != template.ID {
+		t.Errorf("Bad linked templates: %#v", templates)
+	}
+	if err = api.TemplatesUnlink([]string{template.ID}, []string{host.ID}, false); err != nil {
+		t.Fatal(err)
+	}
+
+	template.Description = "updated"
+	if err = api.TemplatesUpdate(Templates{*template}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTemplatesMass(t *testing.T) {
+	for _, c := range []struct {
+		call     func(api *API) error
+		expected string
+	}{{
+		func(api *API) error { return api.TemplatesUnlink([]string{"1", "2"}, []string{"10", "11"}, false) },
+		`host.massremove {"hostids":["10","11"],"templateids":["1","2"]}`,
+	}, {
+		func(api *API) error { return api.TemplatesUnlink([]string{"1", "2"}, []string{"10", "11"}, true) },
+		`host.massremove {"hostids":["10","11"],"templateids_clear":["1","2"]}`,
+	}, {
+		func(api *API) error { return api.TemplatesLinkTemplates([]string{"1", "2"}, []string{"3"}) },
+		`template.massadd {"templates":[{"templateid":"1"},{"templateid":"2"}],"templates_link":[{"templateid":"3"}]}`,
+	}, {
+		func(api *API) error { return api.TemplatesUnlinkTemplates([]string{"1", "2"}, []string{"3"}, true) },
+		`template.massremove {"templateids":["1","2"],"templateids_clear":["3"]}`,
+	}, {
+		func(api *API) error {
+			return api.TemplatesMassRemove(TemplateMassRemove{TemplateIds: []string{"1", "2"}, GroupIds: []string{"5"}})
+		},
+		`template.massremove {"groupids":["5"],"templateids":["1","2"]}`,
+	}} {
+		api, calls := newMethodAPI(map[string]string{
+			"template.massadd":    `{"templateids":["1","2"]}`,
+			"template.massremove": `{"templateids":["1","2"]}`,
+			"host.massremove":     `{"hostids":["10","11"]}`,
+		})
+		err := c.call(api)
+		actual := (*calls)[0].Method + " " + string((*calls)[0].Params)
+		if actual != c.expected {
+			t.Errorf("expected %s\ngot      %s", c.expected, actual)
+		}
+		if err != nil {
+			t.Errorf("%s: %s", c.expected, err)
+		}
+	}
+
+	// host.massadd request is the same for all versions
+	for _, v := range []ServerVersion{{3, 0, 0}, {5, 0, 0}, {6, 2, 0}, {7, 0, 0}} {
+		api, calls := newMethodAPI(map[string]string{"host.massadd": `{"hostids":["10","11"]}`})
+		api.SetVersion(v)
+		if err := api.TemplatesLink([]string{"1", "2"}, []string{"10", "11"}); err != nil {
+			t.Errorf("%s: %s", v, err)
+		}
+		expected := `host.massadd {"hosts":[{"hostid":"10"},{"hostid":"11"}],"templates":[{"templateid":"1"},{"templateid":"2"}]}`
+		if actual := (*calls)[0].Method + " " + string((*calls)[0].Params); actual != expected {
+			t.Errorf("%s: expected %s\ngot      %s", v, expected, actual)
+		}
+	}
+
+	templates := Templates{{Host: "t", Tags: Tags{{Tag: "a"}}, ParentTemplates: Templates{{ID: "1"}}}}
+	params, err := templates.params(ServerVersion{4, 0, 0}.Capabilities())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, present := params[0]["tags"]; present {
+		t.Error("tags should be removed for 4.0")
+	}
+	if _, present := params[0]["parentTemplates"]; present {
+		t.Error("parentTemplates should be removed")
+	}
+}
+
+func TestTemplateGroups(t *testing.T) {
+	for version, prefix := range map[string]string{"6.0.0": "hostgroup", "6.2.0": "templategroup"} {
+		api, calls := newMethodAPI(map[string]string{
+			prefix + ".get":    `[{"groupid":"1","name":"Templates"}]`,
+			prefix + ".create": `{"groupids":["2"]}`,
+			prefix + ".delete": `{"groupids":["2"]}`,
+		})
+		v, _ := ParseVersion(version)
+		api.SetVersion(v)
+
+		groups, err := api.TemplateGroupsGet(Params{})
+		if err != nil || len(groups) != 1 || groups[0].ID != "1" {
+			t.Errorf("%s: unexpected groups %v, %v", version, groups, err)
+		}
+		created := HostGroups{{Name: "Custom templates"}}
+		if err = api.TemplateGroupsCreate(created); err != nil || created[0].ID != "2" {
+			t.Errorf("%s: unexpected group %v, %v", version, created, err)
+		}
+		if err = api.TemplateGroupsDelete(created); err != nil || created[0].ID != "" {
+			t.Errorf("%s: unexpected group %v, %v", version, created, err)
+		}
+		for i, method := range []string{".get", ".create", ".delete"} {
+			if (*calls)[i].Method != prefix+method {
+				t.Errorf("%s: expected %s, got %s", version, prefix+method, (*calls)[i].Method)
+			}
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package zabbix
+
+import (
+	"context"
+)
+
+type (
+	// TokenStatusType - token status type
+	TokenStatusType int
+)
+
+const (
+	// TokenEnabled - (default) enabled token
+	TokenEnabled TokenStatusType = 0
+	// TokenDisabled - disabled token
+	TokenDisabled TokenStatusType = 1
+)
+
+// UnmarshalJSON - json.Unmarshaler interface impl, accepts both number and string
+func (t *TokenStatusType) UnmarshalJSON(b []byte) error {
+	return unmarshalInt(b, (*int)(t))
+}
+
+// Token - https://www.zabbix.com/documentation/5.4/manual/api/reference/token/object
+type Token struct {
+	ID            string          `json:"tokenid,omitempty"`
+	Name          string          `json:"name"`
+	Description   string          `json:"description,omitempty"`
+	UserID        string          `json:"userid,omitempty"`
+	Status        TokenStatusType `json:"status"`
+	ExpiresAt     string          `json:"expires_at,omitempty"`
+	LastAccess    string          `json:"lastaccess,omitempty"`
+	CreatedAt     string          `json:"created_at,omitempty"`
+	CreatorUserID string          `json:"creator_userid,omitempty"`
+}
+
+// Tokens - the array of Token
+type Tokens []Token
+
+// tokens - Returns Resource for token methods.
+func (api *API) tokens() *Resource[Token] {
+	return NewResource(api, "token", "tokenid", func(t *Token) *string { return &t.ID })
+}
+
+// TokensGet - Wrapper for token.get: https://www.zabbix.com/documentation/5.4/manual/api/reference/token/get
+func (api *API) TokensGet(params Params) (res Tokens, err error) {
+	return api.TokensGetContext(context.Background(), params)
+}
+
+// TokensGetContext - Same as TokensGet, but bound to ctx.
+func (api *API) TokensGetContext(ctx context.Context, params Params) (res Tokens, err error) {
+	res, err = api.tokens().GetContext(ctx, params)
+	return
+}
+
+// TokensCreate - Wrapper for token.create: https://www.zabbix.com/documentation/5.4/manual/api/reference/token/create
+// Created tokens have no secret yet, use TokensGenerate() to get it.
+func (api *API) TokensCreate(tokens Tokens) (err error) {
+	return api.TokensCreateContext(context.Background(), tokens)
+}
+
+// TokensCreateContext - Same as TokensCreate, but bound to ctx.
+func (api *API) TokensCreateContext(ctx context.Context, tokens Tokens) (err error) {
+	return api.tokens().CreateContext(ctx, tokens)
+}
+
+// TokensGenerate - Wrapper for token.generate: https://www.zabbix.com/documentation/5.4/manual/api/reference/token/generate
+// Returns map from token Id to newly generated secret, which can be passed to SetAuth().
+func (api *API) TokensGenerate(ids []string) (res map[string]string, err error) {
+	return api.TokensGenerateContext(context.Background(), ids)
+}
+
+// TokensGenerateContext - Same as TokensGenerate, but bound to ctx.
+func (api *API) TokensGenerateContext(ctx context.Context, ids []string) (res map[string]string, err error) {
+	response, err := api.callRaw(ctx, "token.generate", ids)
+	if err != nil {
+		return
+	}
+
+	var result []struct {
+		TokenID string `json:"tokenid"`
+		Token   string `json:"token"`
+	}
+	if err = decodeResult("token.generate", response.Result, &result); err != nil {
+		return
+	}
+
+	res = make(map[string]string, len(result))
+	for _, r := range result {
+		res[r.TokenID] = r.Token
+	}
+	return
+}
+
+// TokensDelete - Wrapper for token.delete: https://www.zabbix.com/documentation/5.4/manual/api/reference/token/delete
+// Cleans ID in all tokens elements if call succeed.
+func (api *API) TokensDelete(tokens Tokens) (err error) {
+	return api.TokensDeleteContext(context.Background(), tokens)
+}
+
+// TokensDeleteContext - Same as TokensDelete, but bound to ctx.
+func (api *API) TokensDeleteContext(ctx context.Context, tokens Tokens) (err error) {
+	return api.tokens().DeleteContext(ctx, tokens)
+}
+
+// TokensDeleteByIds - Wrapper for token.delete: https://www.zabbix.com/documentation/5.4/manual/api/reference/token/delete
+func (api *API) TokensDeleteByIds(ids []string) (err error) {
+	return api.TokensDeleteByIdsContext(context.Background(), ids)
+}
+
+// TokensDeleteByIdsContext - Same as TokensDeleteByIds, but bound to ctx.
+func (api *API) TokensDeleteByIdsContext(ctx context.Context, ids []string) (err error) {
+	return api.tokens().DeleteByIdsContext(ctx, ids)
+}
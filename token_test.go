@@ -0,0 +1,66 @@
+package zabbix
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newVersionServer(version string, t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req request
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.Method == "APIInfo.version" {
+			fmt.Fprintf(w, `{"jsonrpc":"2.0","result":%q,"id":%d}`, version, req.ID)
+			return
+		}
+
+		header := r.Header.Get("Authorization")
+		if header != "" && req.Auth != "" {
+			t.Errorf("Auth passed twice: %q and %q", header, req.Auth)
+		}
+		fmt.Fprintf(w, `{"jsonrpc":"2.0","result":[%q, %q],"id":%d}`, header, req.Auth, req.ID)
+	}))
+}
+
+func TestTokenAuth(t *testing.T) {
+	for version, expected := range map[string][]interface{}{
+		"5.4.0": {"", "secret"},
+		"6.4.0": {"Bearer secret", ""},
+		"7.0.1": {"Bearer secret", ""},
+	} {
+		srv := newVersionServer(version, t)
+		res, err := NewAPIWithToken(srv.URL, "secret").CallWithError("host.get", Params{})
+		srv.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fmt.Sprint(res.Result) != fmt.Sprint(expected) {
+			t.Errorf("%s: expected %v, got %v", version, expected, res.Result)
+		}
+	}
+}
+
+func TestAuthBodyMode(t *testing.T) {
+	for version, ok := range map[string]bool{"6.4.0": true, "7.2.0": false} {
+		srv := newVersionServer(version, t)
+		api := NewAPIWithToken(srv.URL, "secret")
+		api.AuthMode = AuthBody
+		res, err := api.CallWithError("host.get", Params{})
+		srv.Close()
+		if !ok {
+			if err == nil {
+				t.Errorf("%s: expected error for auth in body", version)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if expected := "[ secret]"; fmt.Sprint(res.Result) != expected {
+			t.Errorf("%s: expected %v, got %v", version, expected, res.Result)
+		}
+	}
+}
@@ -0,0 +1,256 @@
+package zabbix
+
+import (
+	"context"
+)
+
+type (
+	// SeverityType - trigger and event severity
+	SeverityType int
+	// TriggerStatusType - trigger status
+	TriggerStatusType int
+	// TriggerValueType - trigger state
+	TriggerValueType int
+	// RecoveryModeType - trigger OK event generation mode
+	RecoveryModeType int
+)
+
+const (
+	// SeverityNotClassified - not classified
+	SeverityNotClassified SeverityType = 0
+	// SeverityInformation - information
+	SeverityInformation SeverityType = 1
+	// SeverityWarning - warning
+	SeverityWarning SeverityType = 2
+	// SeverityAverage - average
+	SeverityAverage SeverityType = 3
+	// SeverityHigh - high
+	SeverityHigh SeverityType = 4
+	// SeverityDisaster - disaster
+	SeverityDisaster SeverityType = 5
+)
+
+const (
+	// TriggerEnabled - enabled
+	TriggerEnabled TriggerStatusType = 0
+	// TriggerDisabled - disabled
+	TriggerDisabled TriggerStatusType = 1
+)
+
+const (
+	// TriggerOK - OK
+	TriggerOK TriggerValueType = 0
+	// TriggerProblem - problem
+	TriggerProblem TriggerValueType = 1
+)
+
+const (
+	// RecoveryExpression - OK event is generated when expression is false
+	RecoveryExpression RecoveryModeType = 0
+	// RecoveryRecoveryExpression - OK event is generated when expression is false and recovery expression is true
+	RecoveryRecoveryExpression RecoveryModeType = 1
+	// RecoveryNone - OK event is never generated, problem is closed manually or by event correlation
+	RecoveryNone RecoveryModeType = 2
+)
+
+// UnmarshalJSON - json.Unmarshaler interface impl, accepts both number and string
+func (t *SeverityType) UnmarshalJSON(b []byte) error {
+	return unmarshalInt(b, (*int)(t))
+}
+
+// UnmarshalJSON - json.Unmarshaler interface impl, accepts both number and string
+func (t *TriggerStatusType) UnmarshalJSON(b []byte) error {
+	return unmarshalInt(b, (*int)(t))
+}
+
+// UnmarshalJSON - json.Unmarshaler interface impl, accepts both number and string
+func (t *TriggerValueType) UnmarshalJSON(b []byte) error {
+	return unmarshalInt(b, (*int)(t))
+}
+
+// UnmarshalJSON - json.Unmarshaler interface impl, accepts both number and string
+func (t *RecoveryModeType) UnmarshalJSON(b []byte) error {
+	return unmarshalInt(b, (*int)(t))
+}
+
+// TriggerID - trigger id
+type TriggerID struct {
+	TriggerID string `json:"triggerid"`
+}
+
+// TriggerIds - trigger ids
+type TriggerIds []TriggerID
+
+// TriggerDependency - dependency of trigger on other trigger, used by TriggersAddDependencies
+type TriggerDependency struct {
+	TriggerID          string `json:"triggerid"`
+	DependsOnTriggerID string `json:"dependsOnTriggerid"`
+}
+
+// Trigger - https://www.zabbix.com/documentation/3.0/manual/api/reference/trigger/object
+type Trigger struct {
+	ID          string            `json:"triggerid,omitempty"`
+	Description string            `json:"description"` // trigger name
+	Expression  string            `json:"expression"`
+	Comments    string            `json:"comments,omitempty"`
+	Priority    SeverityType      `json:"priority"`
+	Status      TriggerStatusType `json:"status"`
+	URL         string            `json:"url,omitempty"`
+	Type        Int               `json:"type"` // 1 - generate multiple problem events
+
+	RecoveryMode       RecoveryModeType `json:"recovery_mode"`                 // 3.2+
+	RecoveryExpression string           `json:"recovery_expression,omitempty"` // 3.2+
+	ManualClose        Int              `json:"manual_close"`                  // 1 - allow manual close, 3.2+
+	CorrelationMode    Int              `json:"correlation_mode"`              // 1 - close problems matching CorrelationTag, 3.2+
+	CorrelationTag     string           `json:"correlation_tag,omitempty"`     // 3.2+
+	Tags               Tags             `json:"tags,omitempty"`                // 3.2+, returned with selectTags
+
+	Dependencies TriggerIds `json:"dependencies,omitempty"` // returned with selectDependencies
+
+	// Fields below are read-only
+	Value      TriggerValueType `json:"value,omitempty"`
+	Error      string           `json:"error,omitempty"`
+	LastChange string           `json:"lastchange,omitempty"`
+	TemplateID string           `json:"templateid,omitempty"`
+}
+
+// Triggers - the array of Trigger
+type Triggers []Trigger
+
+// params - Returns triggers as create/update params for server with capabilities c: removed and read-only fields are omitted.
+func (triggers Triggers) params(c Capabilities) (res []map[string]interface{}, err error) {
+	fields := []string{"value", "error", "lastchange", "templateid"}
+	if !c.TriggerRecovery {
+		fields = append(fields, "recovery_mode", "recovery_expression", "manual_close", "correlation_mode", "correlation_tag", "tags")
+	}
+	return withoutFields(triggers, fields...)
+}
+
+// triggers - Returns Resource for trigger methods.
+func (api *API) triggers() *Resource[Trigger] {
+	r := NewResource(api, "trigger", "triggerid", func(t *Trigger) *string { return &t.ID })
+	r.Params = func(triggers []Trigger, c Capabilities) ([]map[string]interface{}, error) {
+		return Triggers(triggers).params(c)
+	}
+	return r
+}
+
+// TriggersGet - Wrapper for trigger.get: https://www.zabbix.com/documentation/3.0/manual/api/reference/trigger/get
+// Expression is returned with host and item key instead of function Id, unless params set "expandExpression".
+func (api *API) TriggersGet(params Params) (res Triggers, err error) {
+	return api.TriggersGetContext(context.Background(), params)
+}
+
+// TriggersGetContext - Same as TriggersGet, but bound to ctx.
+func (api *API) TriggersGetContext(ctx context.Context, params Params) (res Triggers, err error) {
+	if _, present := params["expandExpression"]; !present {
+		params["expandExpression"] = true
+	}
+	res, err = api.triggers().GetContext(ctx, params)
+	return
+}
+
+// TriggersGetByHostIds - Gets triggers by host Ids.
+func (api *API) TriggersGetByHostIds(ids []string) (res Triggers, err error) {
+	return api.TriggersGetByHostIdsContext(context.Background(), ids)
+}
+
+// TriggersGetByHostIdsContext - Same as TriggersGetByHostIds, but bound to ctx.
+func (api *API) TriggersGetByHostIdsContext(ctx context.Context, ids []string) (res Triggers, err error) {
+	return api.TriggersGetContext(ctx, Params{"hostids": ids})
+}
+
+// TriggerGetByID - Gets trigger by Id only if there is exactly 1 matching trigger. Tags and dependencies are selected too.
+func (api *API) TriggerGetByID(id string) (res *Trigger, err error) {
+	return api.TriggerGetByIDContext(context.Background(), id)
+}
+
+// TriggerGetByIDContext - Same as TriggerGetByID, but bound to ctx.
+func (api *API) TriggerGetByIDContext(ctx context.Context, id string) (res *Trigger, err error) {
+	params := Params{"triggerids": id, "selectDependencies": []string{"triggerid"}, "expandExpression": true}
+	if caps, e := api.CapabilitiesContext(ctx); e == nil && caps.TriggerRecovery {
+		params["selectTags"] = "extend"
+	}
+	return api.triggers().GetOneContext(ctx, params)
+}
+
+// TriggersCreate - Wrapper for trigger.create: https://www.zabbix.com/documentation/3.0/manual/api/reference/trigger/create
+// Fields not supported by server version are omitted; if version is unknown, triggers are sent as is.
+func (api *API) TriggersCreate(triggers Triggers) (err error) {
+	return api.TriggersCreateContext(context.Background(), triggers)
+}
+
+// TriggersCreateContext - Same as TriggersCreate, but bound to ctx.
+func (api *API) TriggersCreateContext(ctx context.Context, triggers Triggers) (err error) {
+	return api.triggers().CreateContext(ctx, triggers)
+}
+
+// TriggersUpdate - Wrapper for trigger.update: https://www.zabbix.com/documentation/3.0/manual/api/reference/trigger/update
+// Fields not supported by server version are omitted; if version is unknown, triggers are sent as is.
+func (api *API) TriggersUpdate(triggers Triggers) (err error) {
+	return api.TriggersUpdateContext(context.Background(), triggers)
+}
+
+// TriggersUpdateContext - Same as TriggersUpdate, but bound to ctx.
+func (api *API) TriggersUpdateContext(ctx context.Context, triggers Triggers) (err error) {
+	return api.triggers().UpdateContext(ctx, triggers)
+}
+
+// TriggersDelete - Wrapper for trigger.delete: https://www.zabbix.com/documentation/3.0/manual/api/reference/trigger/delete
+// Cleans ID in all triggers elements if call succeed.
+func (api *API) TriggersDelete(triggers Triggers) (err error) {
+	return api.TriggersDeleteContext(context.Background(), triggers)
+}
+
+// TriggersDeleteContext - Same as TriggersDelete, but bound to ctx.
+func (api *API) TriggersDeleteContext(ctx context.Context, triggers Triggers) (err error) {
+	return api.triggers().DeleteContext(ctx, triggers)
+}
+
+// TriggersDeleteByIds - Wrapper for trigger.delete: https://www.zabbix.com/documentation/3.0/manual/api/reference/trigger/delete
+func (api *API) TriggersDeleteByIds(ids []string) (err error) {
+	return api.TriggersDeleteByIdsContext(context.Background(), ids)
+}
+
+// TriggersDeleteByIdsContext - Same as TriggersDeleteByIds, but bound to ctx.
+func (api *API) TriggersDeleteByIdsContext(ctx context.Context, ids []string) (err error) {
+	return api.triggers().DeleteByIdsContext(ctx, ids)
+}
+
+// TriggersAddDependencies - Wrapper for trigger.adddependencies: https://www.zabbix.com/documentation/3.0/manual/api/reference/trigger/adddependencies
+func (api *API) TriggersAddDependencies(deps []TriggerDependency) (err error) {
+	return api.TriggersAddDependenciesContext(context.Background(), deps)
+}
+
+// TriggersAddDependenciesContext - Same as TriggersAddDependencies, but bound to ctx.
+func (api *API) TriggersAddDependenciesContext(ctx context.Context, deps []TriggerDependency) (err error) {
+	response, err := api.callRaw(ctx, "trigger.adddependencies", deps)
+	if err != nil {
+		return
+	}
+
+	// result contains Ids of dependent triggers, one per trigger rather than per dependency
+	_, err = resultIds("trigger.adddependencies", response.Result, "triggerids")
+	return
+}
+
+// TriggersDeleteDependencies - Wrapper for trigger.deletedependencies: https://www.zabbix.com/documentation/3.0/manual/api/reference/trigger/deletedependencies
+// Removes all dependencies of triggers with given Ids.
+func (api *API) TriggersDeleteDependencies(ids []string) (err error) {
+	return api.TriggersDeleteDependenciesContext(context.Background(), ids)
+}
+
+// TriggersDeleteDependenciesContext - Same as TriggersDeleteDependencies, but bound to ctx.
+func (api *API) TriggersDeleteDependenciesContext(ctx context.Context, ids []string) (err error) {
+	triggerIds := make(TriggerIds, len(ids))
+	for i, id := range ids {
+		triggerIds[i].TriggerID = id
+	}
+	response, err := api.callRaw(ctx, "trigger.deletedependencies", triggerIds)
+	if err != nil {
+		return
+	}
+
+	_, err = resultIds("trigger.deletedependencies", response.Result, "triggerids")
+	return
+}
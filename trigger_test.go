@@ -0,0 +1,164 @@
+package zabbix
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func CreateTrigger(host *Host, item *Item, t *testing.T) *Trigger {
+	v, err := getAPI(t).DetectVersion()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// expression syntax was changed in 5.4
+	expression := "{" + host.Host + ":" + item.Key + ".last()}>0"
+	if v.AtLeast(5, 4) {
+		expression = "last(/" + host.Host + "/" + item.Key + ")>0"
+	}
+
+	triggers := Triggers{{
+		Description: "trigger for key",
+		Expression:  expression,
+		Priority:    SeverityWarning,
+	}}
+	err = getAPI(t).TriggersCreate(triggers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &triggers[0]
+}
+
+func DeleteTrigger(trigger *Trigger, t *testing.T) {
+	err := getAPI(t).TriggersDelete(Triggers{*trigger})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTriggers(t *testing.T) {
+	api := getAPI(t)
+
+	group := CreateHostGroup(t)
+	defer DeleteHostGroup(group, t)
+
+	host := CreateHost(group, t)
+	defer DeleteHost(host, t)
+
+	app := CreateApplication(host, t)
+	defer DeleteApplication(app, t)
+
+	item := CreateItem(app, t)
+	defer DeleteItem(item, t)
+
+	triggers, err := api.TriggersGetByHostIds([]string{host.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(triggers) != 0 {
+		t.Fatal("Found triggers")
+	}
+
+	trigger := CreateTrigger(host, item, t)
+	other := CreateTrigger(host, item, t)
+
+	err = api.TriggersAddDependencies([]TriggerDependency{{TriggerID: trigger.ID, DependsOnTriggerID: other.ID}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := api.TriggerGetByID(trigger.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Dependencies) != 1 || got.Dependencies[0].TriggerID != other.ID {
+		t.Errorf("Bad dependencies: %#v", got.Dependencies)
+	}
+	if got.Priority != SeverityWarning {
+		t.Errorf("Bad priority: %d", got.Priority)
+	}
+
+	if err = api.TriggersDeleteDependencies([]string{trigger.ID}); err != nil {
+		t.Fatal(err)
+	}
+
+	trigger.Priority = SeverityHigh
+	if err = api.TriggersUpdate(Triggers{*trigger}); err != nil {
+		t.Fatal(err)
+	}
+
+	DeleteTrigger(other, t)
+	DeleteTrigger(trigger, t)
+}
+
+func TestTriggersParams(t *testing.T) {
+	triggers := Triggers{{
+		Description:  "name",
+		Expression:   "expr",
+		Priority:     SeverityHigh,
+		RecoveryMode: RecoveryNone,
+		ManualClose:  1,
+		Tags:         Tags{{Tag: "service", Value: "web"}},
+		Dependencies: TriggerIds{{"1"}},
+		Value:        TriggerProblem,
+		LastChange:   "1500000000",
+	}}
+
+	for version, expected := range map[string]string{
+		"3.0.0": `[{"dependencies":[{"triggerid":"1"}],"description":"name","expression":"expr","priority":4,"status":0,"type":0}]`,
+		"3.2.0": `[{"correlation_mode":0,"dependencies":[{"triggerid":"1"}],"description":"name","expression":"expr","manual_close":1,"priority":4,"recovery_mode":2,"status":0,"tags":[{"tag":"service","value":"web"}],"type":0}]`,
+	} {
+		v, _ := ParseVersion(version)
+		params, err := triggers.params(v.Capabilities())
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, _ := json.Marshal(params)
+		if string(b) != expected {
+			t.Errorf("%s:\nexpected %s\ngot      %s", version, expected, b)
+		}
+	}
+
+	var trigger Trigger
+	err := json.Unmarshal([]byte(`{"triggerid":"1","priority":"5","status":"1","value":"1","recovery_mode":"1","manual_close":"1"}`), &trigger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if trigger.Priority != SeverityDisaster || trigger.Status != TriggerDisabled || trigger.Value != TriggerProblem ||
+		trigger.RecoveryMode != RecoveryRecoveryExpression || trigger.ManualClose != 1 {
+		t.Errorf("Bad trigger: %#v", trigger)
+	}
+}
+
+func TestTriggersGet(t *testing.T) {
+	api, calls := newMethodAPI(map[string]string{"trigger.get": `[{"triggerid":"1"}]`})
+	if _, err := api.TriggersGet(Params{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := api.TriggersGet(Params{"expandExpression": false}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := api.TriggerGetByID("1"); err != nil {
+		t.Fatal(err)
+	}
+	for i, expected := range []string{"true", "false", "true"} {
+		var params map[string]json.RawMessage
+		json.Unmarshal((*calls)[i].Params, &params)
+		if string(params["expandExpression"]) != expected {
+			t.Errorf("Call %d: expected expandExpression %s, got %s", i, expected, params["expandExpression"])
+		}
+	}
+
+}
+
+func TestTriggersUpdateZero(t *testing.T) {
+	// zero values are sent, so trigger can be enabled and its priority reset
+	api, calls := newMethodAPI(map[string]string{"trigger.update": `{"triggerids":["1"]}`})
+	api.SetVersion(ServerVersion{3, 2, 0})
+	err := api.TriggersUpdate(Triggers{{ID: "1", Description: "d", Expression: "e", Status: TriggerEnabled}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `[{"correlation_mode":0,"description":"d","expression":"e","manual_close":0,"priority":0,"recovery_mode":0,"status":0,"triggerid":"1","type":0}]`
+	if p := string((*calls)[0].Params); p != expected {
+		t.Errorf("expected %s\ngot      %s", expected, p)
+	}
+}
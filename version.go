@@ -0,0 +1,161 @@
+package zabbix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ServerVersion - parsed Zabbix server version
+type ServerVersion struct {
+	Major int
+	Minor int
+	Patch int
+}
+
+// ParseVersion - Parses version returned by APIInfo.version, like "3.0.4" or "7.0.0rc1".
+func ParseVersion(s string) (v ServerVersion, err error) {
+	parts := strings.SplitN(strings.TrimSpace(s), ".", 3)
+	if len(parts) < 2 {
+		err = fmt.Errorf("Unexpected version %q.", s)
+		return
+	}
+	if v.Major, err = strconv.Atoi(parts[0]); err != nil {
+		err = fmt.Errorf("Unexpected version %q.", s)
+		return
+	}
+	if v.Minor, err = strconv.Atoi(parts[1]); err != nil {
+		err = fmt.Errorf("Unexpected version %q.", s)
+		return
+	}
+	if len(parts) == 3 {
+		// patch may have suffix like "0rc1", it is ignored
+		patch := parts[2]
+		if i := strings.IndexFunc(patch, func(r rune) bool { return r < '0' || r > '9' }); i >= 0 {
+			patch = patch[:i]
+		}
+		v.Patch, _ = strconv.Atoi(patch)
+	}
+	return
+}
+
+// String - Stringer interface impl
+func (v ServerVersion) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// AtLeast - Returns true if version is major.minor or newer.
+func (v ServerVersion) AtLeast(major, minor int) bool {
+	return v.Major > major || (v.Major == major && v.Minor >= minor)
+}
+
+// Capabilities - API features which depend on server version
+type Capabilities struct {
+	HostDeleteIds   bool // host.delete takes array of Ids instead of objects, 2.4+
+	ItemDataType    bool // item data_type and delta fields, removed in 3.4
+	Preprocessing   bool // item preprocessing, replacement of data_type and delta, 3.4+
+	ErrorHandler    bool // item preprocessing error_handler, 4.0+
+	Suppression     bool // problem and event suppression by maintenance, 4.0+
+	AckClose        bool // event.acknowledge close action, 3.4+
+	AckActions      bool // event.acknowledge action bitmask: close, acknowledge, message and severity, 4.0+
+	AckUnack        bool // event.acknowledge unacknowledge action, 5.0+
+	AckSuppress     bool // event.acknowledge suppress and unsuppress actions, 6.4+
+	TriggerRecovery bool // trigger recovery expression, manual close, correlation, trigger and event tags, 3.2+
+	HostTags        bool // host tags, 4.2+
+	SNMPDetails     bool // SNMP details of host interface, replacement of SNMP item fields, 5.0+
+	SecretMacros    bool // secret user macros, 5.0+
+	VaultMacros     bool // vault user macros, 5.2+
+	Applications    bool // application.* methods and item applications, removed in 5.4
+	ItemTags        bool // item tags, replacement of applications, 5.4+
+	TokenAuth       bool // API tokens, 5.4+
+	UsernameLogin   bool // user.login takes "username" instead of "user", 5.4+
+	TemplateGroups  bool // templategroup.* methods, 6.2+
+	AuthHeader      bool // "Authorization: Bearer" header, 6.4+
+	AuthBody        bool // "auth" field of request body, removed in 7.2
+	HistoryPush     bool // history.push method, 7.0+
+}
+
+// Capabilities - Returns features supported by this version.
+func (v ServerVersion) Capabilities() Capabilities {
+	return Capabilities{
+		HostDeleteIds:   v.AtLeast(2, 4),
+		ItemDataType:    !v.AtLeast(3, 4),
+		Preprocessing:   v.AtLeast(3, 4),
+		ErrorHandler:    v.AtLeast(4, 0),
+		Suppression:     v.AtLeast(4, 0),
+		AckClose:        v.AtLeast(3, 4),
+		AckActions:      v.AtLeast(4, 0),
+		AckUnack:        v.AtLeast(5, 0),
+		AckSuppress:     v.AtLeast(6, 4),
+		TriggerRecovery: v.AtLeast(3, 2),
+		HostTags:        v.AtLeast(4, 2),
+		SNMPDetails:     v.AtLeast(5, 0),
+		SecretMacros:    v.AtLeast(5, 0),
+		VaultMacros:     v.AtLeast(5, 2),
+		Applications:    !v.AtLeast(5, 4),
+		ItemTags:        v.AtLeast(5, 4),
+		TokenAuth:       v.AtLeast(5, 4),
+		UsernameLogin:   v.AtLeast(5, 4),
+		TemplateGroups:  v.AtLeast(6, 2),
+		AuthHeader:      v.AtLeast(6, 4),
+		AuthBody:        !v.AtLeast(7, 2),
+		HistoryPush:     v.AtLeast(7, 0),
+	}
+}
+
+// DetectVersion - Calls Version() once and caches parsed result. Failures are not cached.
+func (api *API) DetectVersion() (v ServerVersion, err error) {
+	return api.DetectVersionContext(context.Background())
+}
+
+// DetectVersionContext - Same as DetectVersion, but bound to ctx.
+func (api *API) DetectVersionContext(ctx context.Context) (v ServerVersion, err error) {
+	api.versionM.Lock()
+	defer api.versionM.Unlock()
+
+	if api.version != nil {
+		return *api.version, nil
+	}
+	s, err := api.VersionContext(ctx)
+	if err != nil {
+		return
+	}
+	if v, err = ParseVersion(s); err != nil {
+		return
+	}
+	api.version = &v
+	return
+}
+
+// SetVersion - Sets server version used instead of detected one, for example to avoid extra call.
+func (api *API) SetVersion(v ServerVersion) {
+	api.versionM.Lock()
+	api.version = &v
+	api.versionM.Unlock()
+}
+
+// Capabilities - Returns features supported by server, detecting its version if needed.
+func (api *API) Capabilities() (c Capabilities, err error) {
+	return api.CapabilitiesContext(context.Background())
+}
+
+// CapabilitiesContext - Same as Capabilities, but bound to ctx.
+func (api *API) CapabilitiesContext(ctx context.Context) (c Capabilities, err error) {
+	v, err := api.DetectVersionContext(ctx)
+	if err == nil {
+		c = v.Capabilities()
+	}
+	return
+}
+
+// require - Returns error matching errors.ErrUnsupported if server version is known and supported returns false
+// for its capabilities. If version is unknown, nil is returned and call is left to server.
+func (api *API) require(ctx context.Context, what string, supported func(c Capabilities) bool) (err error) {
+	v, e := api.DetectVersionContext(ctx)
+	if e == nil && !supported(v.Capabilities()) {
+		err = fmt.Errorf("%s is not supported by Zabbix %s: %w", what, v, errors.ErrUnsupported)
+	}
+	return
+}
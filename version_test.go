@@ -0,0 +1,60 @@
+package zabbix
+
+import (
+	"testing"
+)
+
+func TestParseVersion(t *testing.T) {
+	for s, expected := range map[string]ServerVersion{
+		"2.2.9":    {2, 2, 9},
+		"3.0.4":    {3, 0, 4},
+		"7.0.0rc1": {7, 0, 0},
+		"6.4":      {6, 4, 0},
+	} {
+		v, err := ParseVersion(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v != expected {
+			t.Errorf("%s: expected %s, got %s", s, expected, v)
+		}
+	}
+
+	if _, err := ParseVersion("zabbix"); err == nil {
+		t.Error("Expected error")
+	}
+}
+
+func TestCapabilities(t *testing.T) {
+	c := ServerVersion{2, 2, 0}.Capabilities()
+	if c.HostDeleteIds || !c.Applications || c.UsernameLogin || !c.AuthBody || c.TriggerRecovery || c.Suppression || c.AckActions || c.AckClose {
+		t.Errorf("Bad 2.2 capabilities: %+v", c)
+	}
+	c = ServerVersion{6, 4, 0}.Capabilities()
+	if !c.HostDeleteIds || c.Applications || !c.UsernameLogin || !c.AuthHeader || !c.TemplateGroups || !c.AckSuppress || c.HistoryPush {
+		t.Errorf("Bad 6.4 capabilities: %+v", c)
+	}
+	c = ServerVersion{7, 2, 0}.Capabilities()
+	if c.AuthBody || !c.TemplateGroups || !c.HistoryPush {
+		t.Errorf("Bad 7.2 capabilities: %+v", c)
+	}
+}
+
+func TestDetectVersion(t *testing.T) {
+	srv := newVersionServer("6.0.12", t)
+	defer srv.Close()
+
+	api := NewAPI(srv.URL)
+	v, err := api.DetectVersion()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v != (ServerVersion{6, 0, 12}) {
+		t.Errorf("Unexpected version %s", v)
+	}
+
+	srv.Close()
+	if v, err = api.DetectVersion(); err != nil || v.Minor != 0 {
+		t.Errorf("Version is not cached: %s %v", v, err)
+	}
+}